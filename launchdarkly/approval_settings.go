@@ -0,0 +1,121 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// LaunchDarkly accepts between 1 and 5 approvals before a change can be applied
+const APPROVALS_MIN = 1
+const APPROVALS_MAX = 5
+
+func approvalSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"required": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"can_review_own_request": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"min_num_approvals": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      APPROVALS_MIN,
+					ValidateFunc: validation.IntBetween(APPROVALS_MIN, APPROVALS_MAX),
+				},
+				"can_apply_declined_changes": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				// Approvals are only required for the flags with one of these tags
+				"required_approval_tags": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// defaultApprovalSettings are the settings of environments without an approval workflow.
+func defaultApprovalSettings() JsonApprovalSettings {
+	return JsonApprovalSettings{
+		Required:                false,
+		CanReviewOwnRequest:     false,
+		MinNumApprovals:         APPROVALS_MIN,
+		CanApplyDeclinedChanges: true,
+		RequiredApprovalTags:    []string{},
+	}
+}
+
+// getApprovalSettings returns the approval_settings block, or nil when there is none.
+func getApprovalSettings(value interface{}) map[string]interface{} {
+	blocks, _ := value.([]interface{})
+	if len(blocks) == 0 {
+		return nil
+	}
+	settings, _ := blocks[0].(map[string]interface{})
+	return settings
+}
+
+// validateApprovalSettings checks that approvals are either required for all the flags, or only for
+// those with one of the required approval tags.
+func validateApprovalSettings(value interface{}) error {
+	settings := getApprovalSettings(value)
+	if settings == nil {
+		return nil
+	}
+
+	required, _ := settings["required"].(bool)
+	if required && len(getListOrSet(settings["required_approval_tags"])) > 0 {
+		return fmt.Errorf("approval_settings: required and required_approval_tags cannot be set at the same time")
+	}
+	return nil
+}
+
+// transformApprovalSettingsFromTerraformFormat returns the approval settings of an environment. Removing
+// the block turns the approval workflow off.
+func transformApprovalSettingsFromTerraformFormat(value interface{}) JsonApprovalSettings {
+	settings := getApprovalSettings(value)
+	if settings == nil {
+		return defaultApprovalSettings()
+	}
+
+	return JsonApprovalSettings{
+		Required:                settings["required"].(bool),
+		CanReviewOwnRequest:     settings["can_review_own_request"].(bool),
+		MinNumApprovals:         settings["min_num_approvals"].(int),
+		CanApplyDeclinedChanges: settings["can_apply_declined_changes"].(bool),
+		RequiredApprovalTags:    transformStringSetFromTerraformFormat(settings["required_approval_tags"]),
+	}
+}
+
+// transformApprovalSettingsFromLaunchDarklyFormat reads back the approval settings. LaunchDarkly returns
+// settings for every environment, they are only read back when the block is managed or when an approval
+// workflow was enabled outside of Terraform, so that environments without the block do not drift.
+func transformApprovalSettingsFromLaunchDarklyFormat(settings *JsonApprovalSettings, managed bool) []map[string]interface{} {
+	if settings == nil || (!managed && !settings.Required && len(settings.RequiredApprovalTags) == 0) {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"required":                   settings.Required,
+		"can_review_own_request":     settings.CanReviewOwnRequest,
+		"min_num_approvals":          settings.MinNumApprovals,
+		"can_apply_declined_changes": settings.CanApplyDeclinedChanges,
+		"required_approval_tags":     settings.RequiredApprovalTags,
+	}}
+}
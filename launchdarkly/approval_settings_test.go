@@ -0,0 +1,70 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestValidateApprovalSettings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		settings      interface{}
+		expectedError bool
+	}{
+		{
+			name:     "no block",
+			settings: []interface{}{},
+		},
+		{
+			name: "required for all flags",
+			settings: []interface{}{map[string]interface{}{
+				"required":               true,
+				"required_approval_tags": schema.NewSet(schema.HashString, []interface{}{}),
+			}},
+		},
+		{
+			name: "required for tagged flags",
+			settings: []interface{}{map[string]interface{}{
+				"required":               false,
+				"required_approval_tags": schema.NewSet(schema.HashString, []interface{}{"critical"}),
+			}},
+		},
+		{
+			name: "both",
+			settings: []interface{}{map[string]interface{}{
+				"required":               true,
+				"required_approval_tags": schema.NewSet(schema.HashString, []interface{}{"critical"}),
+			}},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateApprovalSettings(testCase.settings)
+			if testCase.expectedError && err == nil {
+				t.Error("expected an error")
+			}
+			if !testCase.expectedError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestTransformApprovalSettingsFromLaunchDarklyFormat(t *testing.T) {
+	defaults := defaultApprovalSettings()
+	if transformed := transformApprovalSettingsFromLaunchDarklyFormat(&defaults, false); len(transformed) != 0 {
+		t.Errorf("the default settings of an environment without the block were read back: %v", transformed)
+	}
+	if transformed := transformApprovalSettingsFromLaunchDarklyFormat(&defaults, true); len(transformed) != 1 {
+		t.Errorf("the settings of an environment with the block were not read back: %v", transformed)
+	}
+
+	enabled := JsonApprovalSettings{Required: true, MinNumApprovals: 2, RequiredApprovalTags: []string{}}
+	transformed := transformApprovalSettingsFromLaunchDarklyFormat(&enabled, false)
+	if len(transformed) != 1 || transformed[0]["min_num_approvals"] != 2 {
+		t.Errorf("an approval workflow enabled outside of Terraform was not read back: %v", transformed)
+	}
+}
@@ -2,112 +2,247 @@ package launchdarkly
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
-	"time"
 	"strconv"
+	"time"
 )
 
+// APIError is returned when LaunchDarkly's API answers with an unexpected HTTP status code.
+type APIError struct {
+	Method     string
+	Url        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s did not return one of the expected HTTP status codes. Got HTTP %d\n%s", e.Method, e.Url, e.StatusCode, e.Body)
+}
+
+// isNotFound tells whether the error means that the requested resource does not exist in LaunchDarkly.
+func isNotFound(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+const defaultHttpTimeout = 30 * time.Second
+
 type Client struct {
-	AccessToken string
+	AccessToken  string
+	ApiHost      string
+	MaxRetries   int
+	MaxRetryWait time.Duration
+	LogPayloads  bool
+	UserAgent    string
+	ApiVersion   string
+
+	httpClient  *http.Client
+	rateLimiter *rateLimiter
+	stopContext context.Context
 }
 
-func (c *Client) GetStatus(url string) (int, error) {
-	status, _, err := c.execute("GET", url, nil, []int{}, 0)
+// newContext returns the context bounding an operation on a resource. It is cancelled when the timeout
+// expires or when Terraform is interrupted.
+func (c *Client) newContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	parent := c.stopContext
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+func (c *Client) GetStatus(ctx context.Context, url string) (int, error) {
+	status, _, err := c.execute(ctx, "GET", url, nil, []int{})
 	return status, err
 }
 
-func (c *Client) Get(url string, expectedStatus []int) (interface{}, error) {
-	_, response, err := c.execute("GET", url, nil, expectedStatus, 0)
+func (c *Client) Get(ctx context.Context, url string, expectedStatus []int) (interface{}, error) {
+	_, response, err := c.execute(ctx, "GET", url, nil, expectedStatus)
+	if err != nil {
+		return nil, err
+	}
 
 	var parsedResponse interface{}
-	json.Unmarshal(response, &parsedResponse)
+	if err := decodeResponse("GET", url, response, &parsedResponse); err != nil {
+		return nil, err
+	}
 
-	return parsedResponse, err
+	return parsedResponse, nil
 }
 
-func (c *Client) GetInto(url string, expectedStatus []int, target interface{}) error {
-	_, response, err := c.execute("GET", url, nil, expectedStatus, 0)
-
-	json.Unmarshal(response, target)
+func (c *Client) GetInto(ctx context.Context, url string, expectedStatus []int, target interface{}) error {
+	_, response, err := c.execute(ctx, "GET", url, nil, expectedStatus)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return decodeResponse("GET", url, response, target)
 }
 
-func (c *Client) Post(url string, body interface{}, expectedStatus []int, target interface{}) error {
-	_, response, err := c.execute("POST", url, body, expectedStatus, 0)
-
-	json.Unmarshal(response, target)
+func (c *Client) Post(ctx context.Context, url string, body interface{}, expectedStatus []int, target interface{}) error {
+	_, response, err := c.execute(ctx, "POST", url, body, expectedStatus)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return decodeResponse("POST", url, response, target)
 }
 
-func (c *Client) Patch(url string, body interface{}, expectedStatus []int, numberOfRetry int) ([]byte, error) {
-	_, response, err := c.execute("PATCH", url, body, expectedStatus, numberOfRetry)
+func (c *Client) Patch(ctx context.Context, url string, body interface{}, expectedStatus []int) ([]byte, error) {
+	_, response, err := c.execute(ctx, "PATCH", url, body, expectedStatus)
 	return response, err
 }
 
-func (c *Client) Delete(url string, expectedStatus []int) error {
-	_, _, err := c.execute("DELETE", url, nil, expectedStatus, 0)
+func (c *Client) Delete(ctx context.Context, url string, expectedStatus []int) error {
+	_, _, err := c.execute(ctx, "DELETE", url, nil, expectedStatus)
 	return err
 }
 
-func (c *Client) execute(method string, url string, body interface{}, expectedStatus []int, numberOfRetry int) (int, []byte, error) {
+// execute sends the request, retrying rate limited requests, server errors and transient network errors
+// up to MaxRetries times. Requests that are not idempotent are only retried when LaunchDarkly did not handle
+// them. When expectedStatus is empty, any non retryable status is returned to the caller.
+func (c *Client) execute(ctx context.Context, method string, url string, body interface{}, expectedStatus []int) (int, []byte, error) {
 	requestBody, err := json.Marshal(body)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return 0, nil, err
-	}
-
-	req.Header.Set("Authorization", c.AccessToken)
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
+		if err != nil {
+			return 0, nil, err
+		}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, nil, err
-	}
+		req.Header.Set("Authorization", c.AccessToken)
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		if len(c.UserAgent) > 0 {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		if len(c.ApiVersion) > 0 {
+			req.Header.Set("LD-API-Version", c.ApiVersion)
+		}
 
-	defer resp.Body.Close()
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return resp.StatusCode, nil, err
-	}
+		logRequest(method, url, req.Header, requestBody, c.LogPayloads)
 
-	println(method + " " + url + " returned HTTP status " + strconv.Itoa(resp.StatusCode))
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return 0, nil, fmt.Errorf("%s %s was not sent: %s", method, url, err)
+		}
 
-	if len(expectedStatus) > 0 {
-		found := false
-		for _, status := range expectedStatus {
-			if status == resp.StatusCode {
-				found = true
-				break
+		resp, err := c.getHttpClient().Do(req)
+		if err != nil {
+			if ctx.Err() == nil && attempt < c.MaxRetries && isRetryableError(method, err) {
+				if err := c.waitBeforeRetry(ctx, method, url, attempt, 0, nil, err.Error()); err != nil {
+					return 0, nil, err
+				}
+				continue
 			}
+			return 0, nil, err
 		}
 
-		if !found {
-			if numberOfRetry > 0 {
-				toRetry := false
-				for _, status := range []int{429} {
-					if status == resp.StatusCode {
-						toRetry = true
-						break
-					}
-				}
-				if toRetry {
-					println("Will retry " + method + " " + url + " after one minute")
-					time.Sleep(time.Minute)
-					return c.execute(method, url, body, expectedStatus, numberOfRetry - 1)
+		c.rateLimiter.Observe(resp.Header)
+
+		responseBody, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			if ctx.Err() == nil && attempt < c.MaxRetries && isRetryableError(method, err) {
+				if err := c.waitBeforeRetry(ctx, method, url, attempt, 0, nil, err.Error()); err != nil {
+					return resp.StatusCode, nil, err
 				}
-			} 
-			return resp.StatusCode, nil, errors.New(method + " " + url + " did not return one of the expected HTTP status codes. Got HTTP " + strconv.Itoa(resp.StatusCode) + "\n" + string(responseBody))
+				continue
+			}
+			return resp.StatusCode, nil, err
+		}
+
+		logResponse(method, url, resp.StatusCode, responseBody)
+
+		if isExpectedStatus(expectedStatus, resp.StatusCode) {
+			return resp.StatusCode, responseBody, nil
+		}
+
+		if attempt < c.MaxRetries && isRetryableStatus(method, resp.StatusCode) {
+			if err := c.waitBeforeRetry(ctx, method, url, attempt, resp.StatusCode, resp.Header, "HTTP "+strconv.Itoa(resp.StatusCode)); err != nil {
+				return resp.StatusCode, nil, err
+			}
+			continue
 		}
-	} 
 
-	return resp.StatusCode, responseBody, nil
+		if len(expectedStatus) == 0 {
+			return resp.StatusCode, responseBody, nil
+		}
+
+		return resp.StatusCode, nil, &APIError{
+			Method:     method,
+			Url:        url,
+			StatusCode: resp.StatusCode,
+			Body:       string(responseBody),
+		}
+	}
+}
+
+func (c *Client) getHttpClient() *http.Client {
+	if c.httpClient == nil {
+		return http.DefaultClient
+	}
+	return c.httpClient
+}
+
+func (c *Client) waitBeforeRetry(ctx context.Context, method string, url string, attempt int, status int, header http.Header, reason string) error {
+	maxWait := c.MaxRetryWait
+	if maxWait <= 0 {
+		maxWait = defaultMaxRetryWait
+	}
+
+	wait := getRetryWait(attempt, status, header, maxWait, time.Now())
+	log.Printf("[DEBUG] Will retry %s %s in %s (%s, retry %d of %d)", method, url, wait, reason, attempt+1, c.MaxRetries)
+	if err := sleep(ctx, wait); err != nil {
+		return fmt.Errorf("%s %s was not retried after %s: %s", method, url, reason, err)
+	}
+	return nil
+}
+
+// decodeResponse unmarshals a response body into target. A body that cannot be decoded (e.g. an HTML
+// error page from a proxy, or a change in LaunchDarkly's schema) is an error rather than a zero value
+// that would end up in the state.
+func decodeResponse(method string, url string, body []byte, target interface{}) error {
+	if target == nil {
+		return nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return fmt.Errorf("%s %s returned an empty response body", method, url)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("%s %s returned a response that could not be decoded: %s\n%s", method, url, err, truncateBody(redactBody(body)))
+	}
+
+	return nil
+}
+
+const maxBodyLengthInErrors = 512
+
+func truncateBody(body string) string {
+	if len(body) <= maxBodyLengthInErrors {
+		return body
+	}
+	return body[:maxBodyLengthInErrors] + "... (truncated)"
+}
+
+func isExpectedStatus(expectedStatus []int, status int) bool {
+	for _, expected := range expectedStatus {
+		if expected == status {
+			return true
+		}
+	}
+	return false
 }
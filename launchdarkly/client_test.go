@@ -0,0 +1,367 @@
+package launchdarkly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestClient(server *httptest.Server) Client {
+	return Client{
+		AccessToken:  "api-test",
+		httpClient:   server.Client(),
+		ApiHost:      server.URL,
+		MaxRetries:   3,
+		MaxRetryWait: time.Millisecond,
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		failures       []int
+		wantedRequests int
+		wantErr        bool
+	}{
+		{
+			name:           "GET retried after rate limit",
+			method:         "GET",
+			failures:       []int{429, 429},
+			wantedRequests: 3,
+		},
+		{
+			name:           "GET retried after server error",
+			method:         "GET",
+			failures:       []int{503},
+			wantedRequests: 2,
+		},
+		{
+			name:           "POST retried after rate limit",
+			method:         "POST",
+			failures:       []int{429},
+			wantedRequests: 2,
+		},
+		{
+			name:           "POST not retried after server error",
+			method:         "POST",
+			failures:       []int{503},
+			wantedRequests: 1,
+			wantErr:        true,
+		},
+		{
+			name:           "PATCH not retried after server error",
+			method:         "PATCH",
+			failures:       []int{502},
+			wantedRequests: 1,
+			wantErr:        true,
+		},
+		{
+			name:           "DELETE retried after rate limit",
+			method:         "DELETE",
+			failures:       []int{429},
+			wantedRequests: 2,
+		},
+		{
+			name:           "PATCH gives up after max retries",
+			method:         "PATCH",
+			failures:       []int{429, 429, 429, 429, 429},
+			wantedRequests: 4,
+			wantErr:        true,
+		},
+		{
+			name:           "client errors are not retried",
+			method:         "PATCH",
+			failures:       []int{400},
+			wantedRequests: 1,
+			wantErr:        true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != testCase.method {
+					t.Errorf("got method (%s) but want (%s)", r.Method, testCase.method)
+				}
+				requests++
+				if requests <= len(testCase.failures) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(testCase.failures[requests-1])
+					return
+				}
+				w.WriteHeader(200)
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			client := newTestClient(server)
+			_, _, err := client.execute(context.Background(), testCase.method, server.URL, nil, []int{200})
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if requests != testCase.wantedRequests {
+				t.Errorf("got %d requests but want %d", requests, testCase.wantedRequests)
+			}
+		})
+	}
+}
+
+func TestClientGetStatusRetriesRateLimit(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	status, err := client.GetStatus(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if status != 404 {
+		t.Errorf("got status %d but want 404", status)
+	}
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         int
+		wantedNotFound bool
+	}{
+		{
+			name:           "not found",
+			status:         404,
+			wantedNotFound: true,
+		},
+		{
+			name:           "server error",
+			status:         500,
+			wantedNotFound: false,
+		},
+		{
+			name:           "unauthorized",
+			status:         401,
+			wantedNotFound: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+				w.Write([]byte(`{"code":"error"}`))
+			}))
+			defer server.Close()
+
+			client := newTestClient(server)
+			client.MaxRetries = 0
+			err := client.GetInto(context.Background(), server.URL, []int{200}, &JsonProject{})
+
+			apiError, ok := err.(*APIError)
+			if !ok {
+				t.Fatalf("got error (%v) but want an *APIError", err)
+			}
+			if apiError.StatusCode != testCase.status || apiError.Method != "GET" || apiError.Body != `{"code":"error"}` {
+				t.Errorf("unexpected API error: %#v", apiError)
+			}
+			if isNotFound(err) != testCase.wantedNotFound {
+				t.Errorf("isNotFound returned %v but want %v", isNotFound(err), testCase.wantedNotFound)
+			}
+		})
+	}
+}
+
+func TestClientDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "valid response",
+			body: `{"key":"my-project","name":"My Project","environments":[]}`,
+		},
+		{
+			name:    "proxy error page",
+			body:    `<html><body>502 Bad Gateway</body></html>`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated json",
+			body:    `{"key":"my-project","na`,
+			wantErr: true,
+		},
+		{
+			name:    "unexpected schema",
+			body:    `{"key":"my-project","name":["not","a","string"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty body",
+			body:    ``,
+			wantErr: true,
+		},
+	}
+
+	methods := map[string]func(client Client, url string) error{
+		"Get": func(client Client, url string) error {
+			_, err := client.Get(context.Background(), url, []int{200})
+			return err
+		},
+		"GetInto": func(client Client, url string) error {
+			return client.GetInto(context.Background(), url, []int{200}, &JsonProject{})
+		},
+		"Post": func(client Client, url string) error {
+			return client.Post(context.Background(), url, JsonProject{Key: "my-project"}, []int{200}, &JsonProject{})
+		},
+	}
+
+	for _, testCase := range testCases {
+		for methodName, method := range methods {
+			// The generic Get decodes into an interface{} so it has no schema to violate
+			if methodName == "Get" && testCase.name == "unexpected schema" {
+				continue
+			}
+
+			t.Run(methodName+" with "+testCase.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(200)
+					w.Write([]byte(testCase.body))
+				}))
+				defer server.Close()
+
+				err := method(newTestClient(server), server.URL+"/projects/my-project")
+				if (err != nil) != testCase.wantErr {
+					t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+				}
+				if err != nil && !strings.Contains(err.Error(), server.URL+"/projects/my-project") {
+					t.Errorf("error does not mention the URL: %s", err)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeResponseTruncatesBody(t *testing.T) {
+	body := "<html>" + strings.Repeat("a", 2*maxBodyLengthInErrors) + "</html>"
+
+	err := decodeResponse("GET", "https://app.launchdarkly.com/api/v2/projects", []byte(body), &JsonProject{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "</html>") || !strings.Contains(err.Error(), "(truncated)") {
+		t.Errorf("the body was not truncated in the error: %s", err)
+	}
+}
+
+func TestClientStopsRetryingWhenContextIsDone(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(429)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.MaxRetryWait = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Patch(ctx, server.URL, []interface{}{}, []int{200})
+	if err == nil {
+		t.Fatal("expected an error when the context is done")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the retry was not interrupted by the context, took %s", elapsed)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests but want 1", requests)
+	}
+}
+
+func TestClientHttpTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(server)
+	client.MaxRetries = 0
+	client.httpClient.Timeout = 50 * time.Millisecond
+
+	err := client.GetInto(context.Background(), server.URL, []int{200}, &JsonProject{})
+	if err == nil {
+		t.Fatal("expected an error when the request times out")
+	}
+}
+
+func TestClientNewContext(t *testing.T) {
+	stopContext, stop := context.WithCancel(context.Background())
+	client := Client{stopContext: stopContext}
+
+	ctx, cancel := client.newContext(time.Hour)
+	defer cancel()
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		t.Error("expected the context to have a deadline")
+	}
+
+	stop()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("expected the context to be done when Terraform is interrupted")
+	}
+}
+
+func TestClientHeaders(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.WriteHeader(200)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.UserAgent = getUserAgent("0.12.8")
+	client.ApiVersion = defaultApiVersion
+
+	if err := client.GetInto(context.Background(), server.URL, []int{200}, &JsonProject{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	wanted := map[string]string{
+		"Authorization":  "api-test",
+		"User-Agent":     "terraform-provider-launchdarkly/" + ProviderVersion + " terraform/0.12.8",
+		"Ld-Api-Version": "20191212",
+	}
+	for name, value := range wanted {
+		if header.Get(name) != value {
+			t.Errorf("got header %s (%s) but want (%s)", name, header.Get(name), value)
+		}
+	}
+}
+
+func TestGetUserAgentWithoutTerraformVersion(t *testing.T) {
+	wanted := "terraform-provider-launchdarkly/" + ProviderVersion + " terraform/0.11+compatible"
+	if userAgent := getUserAgent(""); userAgent != wanted {
+		t.Errorf("got (%s) but want (%s)", userAgent, wanted)
+	}
+}
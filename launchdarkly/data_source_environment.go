@@ -6,7 +6,7 @@ import (
 
 func dataSourceEnvironment() *schema.Resource {
 	return &schema.Resource{
-		Read: resourceEnvironmentRead,
+		Read: dataSourceEnvironmentRead,
 
 		Schema: map[string]*schema.Schema{
 			"project_key": {
@@ -35,6 +35,65 @@ func dataSourceEnvironment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"secure_mode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"default_ttl": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"default_track_events": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"require_comments": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"confirm_changes": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"approval_settings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"can_review_own_request": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"min_num_approvals": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"can_apply_declined_changes": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"required_approval_tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 	}
 }
+
+// The data source has no approval settings to compare with, they are always read.
+func dataSourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	return readEnvironment(d, m, true)
+}
@@ -0,0 +1,98 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceMetric() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetricRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKey,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"kind": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"selector": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"urls": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"substring": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pattern": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"event_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_numeric": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"unit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"success_criteria": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// dataSourceMetricRead fails when the metric does not exist, rather than leaving the data source empty.
+func dataSourceMetricRead(d *schema.ResourceData, m interface{}) error {
+	if err := resourceMetricRead(d, m); err != nil {
+		return err
+	}
+	if len(d.Id()) == 0 {
+		return fmt.Errorf("no metric found with key %s in project %s", d.Get("key"), d.Get("project_key"))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceSegment() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSegmentRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKey,
+			},
+			"env_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKey,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"included": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"excluded": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clauses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"operator": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"value_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"negate": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceSegmentRead fails when the segment does not exist, rather than leaving the data source empty.
+func dataSourceSegmentRead(d *schema.ResourceData, m interface{}) error {
+	if err := resourceSegmentRead(d, m); err != nil {
+		return err
+	}
+	if len(d.Id()) == 0 {
+		return fmt.Errorf("no segment found with key %s in environment %s of project %s", d.Get("key"), d.Get("env_key"), d.Get("project_key"))
+	}
+	return nil
+}
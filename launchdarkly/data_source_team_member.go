@@ -0,0 +1,83 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Maximum number of members returned by each request when looking up a member
+const membersPageSize = 100
+
+func dataSourceTeamMember() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTeamMemberRead,
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"first_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"custom_roles": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceTeamMemberRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	email := d.Get("email").(string)
+
+	member, err := getMemberByEmail(ctx, client, email)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return fmt.Errorf("no team member found with email %s", email)
+	}
+
+	return setTeamMember(ctx, client, d, *member)
+}
+
+// getMemberByEmail looks for a member in all the pages of the members of the account, since the API
+// cannot filter them by email. It returns nil when there is no such member.
+func getMemberByEmail(ctx context.Context, client Client, email string) (*JsonMember, error) {
+	// The offset moves by the number of members returned, in case LaunchDarkly returns less than asked
+	for offset := 0; ; {
+		var response JsonMembers
+		err := client.GetInto(ctx, client.getMembersPageUrl(membersPageSize, offset), []int{200}, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range response.Items {
+			if member.Email == email {
+				return &member, nil
+			}
+		}
+
+		offset += len(response.Items)
+		if len(response.Items) == 0 || offset >= response.TotalCount {
+			return nil, nil
+		}
+	}
+}
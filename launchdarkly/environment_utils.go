@@ -1,14 +1,15 @@
 package launchdarkly
 
 import (
-	"strconv"
+	"context"
+	"log"
 )
 
 const dummyEnvironmentKey = "dummy-environment"
 
-func getEnvironmentKeys(client Client, project string) ([]string, error) {
+func getEnvironmentKeys(ctx context.Context, client Client, project string) ([]string, error) {
 	var response JsonProject
-	err := client.GetInto(getProjectUrl(project), []int{200}, &response)
+	err := client.GetInto(ctx, client.getProjectUrl(project), []int{200}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -21,50 +22,50 @@ func getEnvironmentKeys(client Client, project string) ([]string, error) {
 	return keys, nil
 }
 
-func ensureWeCanDeleteEnvironment(client Client, project string) error {
-	onlyOne, err := isThereOnlyOneEnvironment(client, project)
+func ensureWeCanDeleteEnvironment(ctx context.Context, client Client, project string) error {
+	onlyOne, err := isThereOnlyOneEnvironment(ctx, client, project)
 	if err != nil {
 		return err
 	}
 
 	if onlyOne {
-		println("Creating dummy environment since we cannot delete the last environment in a project")
-		return ensureThereIsADummyEnvironment(client, project)
+		log.Printf("[DEBUG] Creating dummy environment since we cannot delete the last environment in project %s", project)
+		return ensureThereIsADummyEnvironment(ctx, client, project)
 	} else {
 		return nil
 	}
 }
 
-func ensureThereIsADummyEnvironment(client Client, project string) error {
-	exists, err := isThereADummyEnvironment(client, project)
+func ensureThereIsADummyEnvironment(ctx context.Context, client Client, project string) error {
+	exists, err := isThereADummyEnvironment(ctx, client, project)
 	if err != nil {
 		return err
 	}
 
 	if !exists {
-		return createDummyEnvironment(client, project)
+		return createDummyEnvironment(ctx, client, project)
 	} else {
 		return nil
 	}
 }
 
-func ensureThereIsNoDummyEnvironment(client Client, project string) error {
-	exists, err := isThereADummyEnvironment(client, project)
+func ensureThereIsNoDummyEnvironment(ctx context.Context, client Client, project string) error {
+	exists, err := isThereADummyEnvironment(ctx, client, project)
 	if err != nil {
 		return err
 	}
 
 	if exists {
-		println("A dummy environment was found, deleting it")
-		return deleteDummyEnvironment(client, project)
+		log.Printf("[DEBUG] A dummy environment was found in project %s, deleting it", project)
+		return deleteDummyEnvironment(ctx, client, project)
 	} else {
-		println("No dummy environment was found")
+		log.Printf("[DEBUG] No dummy environment was found in project %s", project)
 		return nil
 	}
 }
 
-func isThereADummyEnvironment(client Client, project string) (bool, error) {
-	statusCode, err := client.GetStatus(getEnvironmentUrl(project, dummyEnvironmentKey))
+func isThereADummyEnvironment(ctx context.Context, client Client, project string) (bool, error) {
+	statusCode, err := client.GetStatus(ctx, client.getEnvironmentUrl(project, dummyEnvironmentKey))
 	if err != nil {
 		return false, err
 	}
@@ -72,20 +73,20 @@ func isThereADummyEnvironment(client Client, project string) (bool, error) {
 	return statusCode == 200, nil
 }
 
-func isThereOnlyOneEnvironment(client Client, project string) (bool, error) {
+func isThereOnlyOneEnvironment(ctx context.Context, client Client, project string) (bool, error) {
 	var response JsonProject
-	err := client.GetInto(getProjectUrl(project), []int{200}, &response)
+	err := client.GetInto(ctx, client.getProjectUrl(project), []int{200}, &response)
 	if err != nil {
 		return false, err
 	}
 
-	println("There are currently " + strconv.Itoa(len(response.Environments)) + " environments in project " + project)
+	log.Printf("[DEBUG] There are currently %d environments in project %s", len(response.Environments), project)
 
 	return len(response.Environments) == 1, nil
 }
 
-func createDummyEnvironment(client Client, project string) error {
-	println("Creating dummy environment")
+func createDummyEnvironment(ctx context.Context, client Client, project string) error {
+	log.Printf("[DEBUG] Creating dummy environment in project %s", project)
 
 	payload := JsonEnvironment{
 		Name:  dummyEnvironmentKey,
@@ -94,7 +95,7 @@ func createDummyEnvironment(client Client, project string) error {
 	}
 
 	var response JsonEnvironment
-	err := client.Post(getEnvironmentCreateUrl(project), payload, []int{201}, &response)
+	err := client.Post(ctx, client.getEnvironmentCreateUrl(project), payload, []int{201}, &response)
 	if err != nil {
 		return err
 	}
@@ -102,10 +103,10 @@ func createDummyEnvironment(client Client, project string) error {
 	return nil
 }
 
-func deleteDummyEnvironment(client Client, project string) error {
-	println("Deleting the dummy environment")
+func deleteDummyEnvironment(ctx context.Context, client Client, project string) error {
+	log.Printf("[DEBUG] Deleting the dummy environment in project %s", project)
 
-	err := client.Delete(getEnvironmentUrl(project, dummyEnvironmentKey), []int{204, 404})
+	err := client.Delete(ctx, client.getEnvironmentUrl(project, dummyEnvironmentKey), []int{204, 404})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,216 @@
+package launchdarkly
+
+import (
+	"fmt"
+)
+
+// The per environment configuration of a feature flag is managed through JSON patches on the flag,
+// under /environments/<environment key>. Settings are given as the map of a Terraform block, and only
+// the settings present in the map are patched so that each resource manages its own subset of them.
+// Prerequisites are resolved against the variations of the flags they reference, see getFlagsVariations.
+func createPayloadForFlagEnvironment(environment string, settings map[string]interface{}, variations []interface{}, prerequisiteFlags map[string][]JsonVariations) ([]map[string]interface{}, error) {
+	var patchPayload []map[string]interface{}
+
+	if on, ok := settings["on"]; ok {
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "on", on.(bool)))
+	}
+
+	if trackEvents, ok := settings["track_events"]; ok {
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "trackEvents", trackEvents.(bool)))
+	}
+
+	if rawFallthrough, ok := settings["fallthrough"]; ok {
+		fallthroughs := rawFallthrough.([]interface{})
+		if len(fallthroughs) > 0 {
+			fallthroughValue, err := transformFallthroughFromTerraformFormat(fallthroughs[0].(map[string]interface{}), variations)
+			if err != nil {
+				return nil, err
+			}
+			patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "fallthrough", fallthroughValue))
+		}
+	}
+
+	if offVariation, ok := settings["off_variation"]; ok && len(offVariation.(string)) > 0 {
+		variationIndex, err := getVariationIndex(variations, offVariation.(string))
+		if err != nil {
+			return nil, err
+		}
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "offVariation", variationIndex))
+	}
+
+	if rules, ok := settings["rules"]; ok {
+		transformedRules, err := transformRulesFromTerraformFormat(rules.([]interface{}), variations)
+		if err != nil {
+			return nil, err
+		}
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "rules", transformedRules))
+	}
+
+	if targets, ok := settings["targets"]; ok {
+		transformedTargets, err := transformTargetsFromTerraformFormat(targets, variations)
+		if err != nil {
+			return nil, err
+		}
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "targets", transformedTargets))
+	}
+
+	if prerequisites, ok := settings["prerequisites"]; ok {
+		transformedPrerequisites, err := transformPrerequisitesFromTerraformFormat(prerequisites.([]interface{}), prerequisiteFlags)
+		if err != nil {
+			return nil, err
+		}
+		patchPayload = append(patchPayload, replaceFlagEnvironmentSetting(environment, "prerequisites", transformedPrerequisites))
+	}
+
+	return patchPayload, nil
+}
+
+// createPayloadForFlagEnvironments creates the patches for the environment blocks of the feature flag
+// resource, each block holding the key of the environment along with its settings.
+func createPayloadForFlagEnvironments(environments []interface{}, variations []interface{}, prerequisiteFlags map[string][]JsonVariations) ([]map[string]interface{}, error) {
+	var patchPayload []map[string]interface{}
+	for _, rawEnvironment := range environments {
+		settings := make(map[string]interface{})
+		for name, value := range rawEnvironment.(map[string]interface{}) {
+			settings[name] = value
+		}
+		key := settings["key"].(string)
+		delete(settings, "key")
+
+		environmentPayload, err := createPayloadForFlagEnvironment(key, settings, variations, prerequisiteFlags)
+		if err != nil {
+			return nil, err
+		}
+		patchPayload = append(patchPayload, environmentPayload...)
+	}
+	return patchPayload, nil
+}
+
+// transformFlagEnvironmentsFromLaunchDarklyFormat reads back the environments managed by the environment
+// blocks of the feature flag resource, keeping their order. Environments that no longer exist are
+// dropped so that Terraform plans to configure them again.
+func transformFlagEnvironmentsFromLaunchDarklyFormat(managedEnvironments []interface{}, flag JsonFeatureFlag, prerequisiteFlags map[string][]JsonVariations) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, 0, len(managedEnvironments))
+	for _, rawEnvironment := range managedEnvironments {
+		key := rawEnvironment.(map[string]interface{})["key"].(string)
+		flagEnvironment, found := flag.Environments[key]
+		if !found {
+			continue
+		}
+
+		transformed = append(transformed, map[string]interface{}{
+			"key":           key,
+			"on":            flagEnvironment.On,
+			"rules":         transformRulesFromLaunchDarklyFormat(flagEnvironment.Rules, flag.Variations),
+			"targets":       transformTargetsFromLaunchDarklyFormat(flagEnvironment.Targets, flag.Variations),
+			"prerequisites": transformPrerequisitesFromLaunchDarklyFormat(flagEnvironment.Prerequisites, prerequisiteFlags),
+		})
+	}
+	return transformed
+}
+
+// getFlagEnvironmentsPrerequisiteFlagKeys returns the keys of the flags referenced as prerequisites by
+// the environment blocks of the feature flag resource.
+func getFlagEnvironmentsPrerequisiteFlagKeys(environments []interface{}) []string {
+	var flagKeys []string
+	for _, rawEnvironment := range environments {
+		environment, ok := rawEnvironment.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prerequisites, _ := environment["prerequisites"].([]interface{})
+		flagKeys = append(flagKeys, getPrerequisiteFlagKeysFromTerraformFormat(prerequisites)...)
+	}
+	return flagKeys
+}
+
+// getManagedFlagEnvironmentsPrerequisiteFlagKeys returns the keys of the flags that are prerequisites
+// in the environments of a flag managed by the environment blocks of the feature flag resource.
+func getManagedFlagEnvironmentsPrerequisiteFlagKeys(managedEnvironments []interface{}, flag JsonFeatureFlag) []string {
+	var flagKeys []string
+	for _, rawEnvironment := range managedEnvironments {
+		key := rawEnvironment.(map[string]interface{})["key"].(string)
+		flagKeys = append(flagKeys, getPrerequisiteFlagKeysFromLaunchDarklyFormat(flag.Environments[key].Prerequisites)...)
+	}
+	return flagKeys
+}
+
+// createPayloadForFlagEnvironmentReset puts the configuration of an environment back to the one of a
+// newly created flag, since the environments of a flag cannot be deleted.
+func createPayloadForFlagEnvironmentReset(environment string, variations []interface{}) ([]map[string]interface{}, error) {
+	fallthroughVariation, err := getDefaultVariationIndex(variations, "")
+	if err != nil {
+		return nil, err
+	}
+	offVariation, err := getDefaultOffVariationIndex(variations, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]interface{}{
+		replaceFlagEnvironmentSetting(environment, "on", false),
+		replaceFlagEnvironmentSetting(environment, "trackEvents", false),
+		replaceFlagEnvironmentSetting(environment, "fallthrough", JsonFallthrough{Variation: &fallthroughVariation}),
+		replaceFlagEnvironmentSetting(environment, "offVariation", offVariation),
+		replaceFlagEnvironmentSetting(environment, "rules", []JsonRule{}),
+		replaceFlagEnvironmentSetting(environment, "targets", []JsonTarget{}),
+		replaceFlagEnvironmentSetting(environment, "prerequisites", []JsonPrerequisite{}),
+	}, nil
+}
+
+// validateFlagEnvironments checks the settings of the environment blocks of the feature flag resource
+// at plan time.
+func validateFlagEnvironments(environments []interface{}) error {
+	for _, rawEnvironment := range environments {
+		environment, ok := rawEnvironment.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules, _ := environment["rules"].([]interface{})
+		if err := validateRules(rules); err != nil {
+			return fmt.Errorf("environment %v: %s", environment["key"], err)
+		}
+	}
+	return nil
+}
+
+func replaceFlagEnvironmentSetting(environment string, setting string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  fmt.Sprintf("/environments/%s/%s", environment, setting),
+		"value": value,
+	}
+}
+
+func transformFallthroughFromTerraformFormat(fallthroughValue map[string]interface{}, variations []interface{}) (JsonFallthrough, error) {
+	variationIndex, rollout, err := transformVariationOrRolloutFromTerraformFormat(fallthroughValue, "variation", variations)
+	if err != nil {
+		return JsonFallthrough{}, err
+	}
+
+	return JsonFallthrough{Variation: variationIndex, Rollout: rollout}, nil
+}
+
+func transformFallthroughFromLaunchDarklyFormat(fallthroughValue JsonFallthrough, variations []JsonVariations) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"variation": getVariationValue(variations, fallthroughValue.Variation),
+		"rollout":   transformRolloutFromLaunchDarklyFormat(fallthroughValue.Rollout, variations),
+	}}
+}
+
+// getVariationsFromLaunchDarklyFormat returns the variations of a flag in the format of the variations
+// attribute of the feature flag resource, so that variation values can be resolved with getVariationIndex.
+func getVariationsFromLaunchDarklyFormat(variations []JsonVariations) []interface{} {
+	transformed := make([]interface{}, len(variations))
+	for index, variation := range transformVariationsFromLaunchDarklyFormat(variations).([]map[string]interface{}) {
+		transformed[index] = variation
+	}
+	return transformed
+}
+
+func getVariationValue(variations []JsonVariations, index *int) string {
+	if index == nil || *index < 0 || *index >= len(variations) {
+		return ""
+	}
+	return formatValue(variations[*index].Value)
+}
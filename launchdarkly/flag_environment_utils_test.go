@@ -0,0 +1,140 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var testVariations = []interface{}{
+	map[string]interface{}{"value": "blue", "name": "", "description": ""},
+	map[string]interface{}{"value": "green", "name": "", "description": ""},
+	map[string]interface{}{"value": "red", "name": "", "description": ""},
+}
+
+func testPayloadVerify(t *testing.T, payload interface{}, wanted string) {
+	marshaled, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Compare the documents rather than the strings so that key ordering does not matter
+	var got, want interface{}
+	json.Unmarshal(marshaled, &got)
+	if err := json.Unmarshal([]byte(wanted), &want); err != nil {
+		t.Fatalf("invalid wanted payload: %s", err)
+	}
+	gotJson, _ := json.Marshal(got)
+	wantJson, _ := json.Marshal(want)
+	if string(gotJson) != string(wantJson) {
+		t.Errorf("got payload %s but want %s", gotJson, wantJson)
+	}
+}
+
+func TestCreatePayloadForFlagEnvironment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings map[string]interface{}
+		wanted   string
+		wantErr  bool
+	}{
+		{
+			name: "all settings",
+			settings: map[string]interface{}{
+				"on":            true,
+				"track_events":  false,
+				"fallthrough":   []interface{}{map[string]interface{}{"variation": "green"}},
+				"off_variation": "red",
+			},
+			wanted: `[
+				{"op": "replace", "path": "/environments/production/on", "value": true},
+				{"op": "replace", "path": "/environments/production/trackEvents", "value": false},
+				{"op": "replace", "path": "/environments/production/fallthrough", "value": {"variation": 1}},
+				{"op": "replace", "path": "/environments/production/offVariation", "value": 2}
+			]`,
+		},
+		{
+			name:     "only the settings that are managed",
+			settings: map[string]interface{}{"on": false},
+			wanted:   `[{"op": "replace", "path": "/environments/production/on", "value": false}]`,
+		},
+		{
+			name: "rules",
+			settings: map[string]interface{}{
+				"rules": []interface{}{map[string]interface{}{
+					"variation": "red",
+					"clauses": []interface{}{map[string]interface{}{
+						"attribute":  "email",
+						"operator":   "endsWith",
+						"values":     []interface{}{"@example.com"},
+						"value_type": "string",
+						"negate":     false,
+					}},
+				}},
+			},
+			wanted: `[{"op": "replace", "path": "/environments/production/rules", "value": [
+				{"variation": 2, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"], "negate": false}]}
+			]}]`,
+		},
+		{
+			name: "unknown variation",
+			settings: map[string]interface{}{
+				"fallthrough": []interface{}{map[string]interface{}{"variation": "yellow"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			payload, err := createPayloadForFlagEnvironment("production", testCase.settings, testVariations, nil)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr {
+				testPayloadVerify(t, payload, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestCreatePayloadForFlagEnvironmentReset(t *testing.T) {
+	payload, err := createPayloadForFlagEnvironmentReset("production", testVariations)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testPayloadVerify(t, payload, `[
+		{"op": "replace", "path": "/environments/production/on", "value": false},
+		{"op": "replace", "path": "/environments/production/trackEvents", "value": false},
+		{"op": "replace", "path": "/environments/production/fallthrough", "value": {"variation": 0}},
+		{"op": "replace", "path": "/environments/production/offVariation", "value": 2},
+		{"op": "replace", "path": "/environments/production/rules", "value": []},
+		{"op": "replace", "path": "/environments/production/targets", "value": []},
+		{"op": "replace", "path": "/environments/production/prerequisites", "value": []}
+	]`)
+}
+
+func TestGetVariationValue(t *testing.T) {
+	variations := []JsonVariations{{Value: true}, {Value: false}, {Value: float64(1000000)}}
+	zero, one, number, outOfRange := 0, 1, 2, 3
+
+	testCases := []struct {
+		name   string
+		index  *int
+		wanted string
+	}{
+		{name: "first", index: &zero, wanted: "true"},
+		{name: "second", index: &one, wanted: "false"},
+		{name: "large number", index: &number, wanted: "1000000"},
+		{name: "out of range", index: &outOfRange, wanted: ""},
+		{name: "unset", index: nil, wanted: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if value := getVariationValue(variations, testCase.index); value != testCase.wanted {
+				t.Errorf("got (%s) but want (%s)", value, testCase.wanted)
+			}
+		})
+	}
+}
@@ -3,9 +3,24 @@ package launchdarkly
 import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Requests are retried with backoff when LaunchDarkly rate limits us, so operations need more time than
+// a single request would suggest.
+const defaultResourceTimeout = 10 * time.Minute
+
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
 func parseCompositeID(id string) (p1 string, p2 string, err error) {
 	parts := strings.SplitN(id, ":", 2)
 	if len(parts) == 2 {
@@ -17,6 +32,18 @@ func parseCompositeID(id string) (p1 string, p2 string, err error) {
 	return
 }
 
+func parseThreePartID(id string) (p1 string, p2 string, p3 string, err error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) == 3 && len(parts[0]) > 0 && len(parts[1]) > 0 && len(parts[2]) > 0 {
+		p1 = parts[0]
+		p2 = parts[1]
+		p3 = parts[2]
+	} else {
+		err = fmt.Errorf("error: Import composite ID requires three parts separated by colons, eg x:y:z")
+	}
+	return
+}
+
 type importFunc func(d *schema.ResourceData, meta interface{}) error
 
 func resourceImport(readMethod importFunc, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -28,7 +55,29 @@ func resourceImport(readMethod importFunc, d *schema.ResourceData, meta interfac
 	d.Set("project_key", projectKey)
 	d.Set("key", resourceKey)
 
-	readMethod(d, meta)
+	if err := readMethod(d, meta); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// transformStringSetFromTerraformFormat returns the sorted strings of a set attribute, so that the
+// payloads sent to LaunchDarkly do not depend on the ordering of the set.
+func transformStringSetFromTerraformFormat(value interface{}) []string {
+	transformed := make([]string, 0)
+	for _, item := range getListOrSet(value) {
+		transformed = append(transformed, item.(string))
+	}
+	sort.Strings(transformed)
+	return transformed
+}
+
+// formatValue returns a value decoded from LaunchDarkly's JSON as it is written in the configuration. JSON
+// numbers are decoded as float64, which fmt.Sprint would render with an exponent (e.g. 1e+06).
+func formatValue(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
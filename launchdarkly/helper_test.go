@@ -134,3 +134,40 @@ func testResourceImportVerify(t *testing.T, resourceData []*schema.ResourceData,
 		}
 	}
 }
+
+func TestParseThreePartID(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      string
+		wanted  []string
+		wantErr bool
+	}{
+		{
+			name:   "expected",
+			id:     "project:flag:env",
+			wanted: []string{"project", "flag", "env"},
+		},
+		{
+			name:    "with two parts",
+			id:      "project:flag",
+			wantErr: true,
+		},
+		{
+			name:    "with an empty part",
+			id:      "project::env",
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p1, p2, p3, err := parseThreePartID(testCase.id)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && !reflect.DeepEqual([]string{p1, p2, p3}, testCase.wanted) {
+				t.Errorf("got (%v) but want (%v)", []string{p1, p2, p3}, testCase.wanted)
+			}
+		})
+	}
+}
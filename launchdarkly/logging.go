@@ -0,0 +1,99 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// Fields of LaunchDarkly's API payloads that hold secrets and must never appear in the logs.
+var sensitiveFields = map[string]bool{
+	"apiKey":    true,
+	"mobileKey": true,
+	"secret":    true,
+	"token":     true,
+	"writeKey":  true,
+}
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+}
+
+func logRequest(method string, url string, header http.Header, body []byte, logPayloads bool) {
+	log.Printf("[DEBUG] LaunchDarkly API request: %s %s", method, url)
+	log.Printf("[TRACE] LaunchDarkly API request headers: %s", formatHeaders(header))
+	if logPayloads && len(body) > 0 && string(body) != "null" {
+		log.Printf("[DEBUG] LaunchDarkly API request payload: %s", redactBody(body))
+	}
+}
+
+func logResponse(method string, url string, status int, body []byte) {
+	log.Printf("[DEBUG] LaunchDarkly API response: %s %s returned HTTP status %d", method, url, status)
+	if len(body) > 0 {
+		log.Printf("[TRACE] LaunchDarkly API response body: %s", redactBody(body))
+	}
+}
+
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := make([]string, len(names))
+	for index, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		formatted[index] = fmt.Sprintf("%s: %s", name, value)
+	}
+
+	return strings.Join(formatted, "; ")
+}
+
+// redactBody replaces the value of sensitive fields anywhere in a JSON document. Bodies that are not
+// JSON (e.g. an HTML error page from a proxy) are returned untouched since they cannot hold our secrets.
+func redactBody(body []byte) string {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if sensitiveFields[key] {
+				typed[key] = redactedValue
+			} else {
+				typed[key] = redactValue(nested)
+			}
+		}
+		// JSON patch operations replacing a secret, e.g. {"op": "replace", "path": "/secret", "value": "..."}
+		if path, ok := typed["path"].(string); ok && sensitiveFields[path[strings.LastIndex(path, "/")+1:]] {
+			if _, hasValue := typed["value"]; hasValue {
+				typed["value"] = redactedValue
+			}
+		}
+	case []interface{}:
+		for index, nested := range typed {
+			typed[index] = redactValue(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package launchdarkly
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	testCases := []struct {
+		name   string
+		body   string
+		wanted string
+	}{
+		{
+			name:   "environment",
+			body:   `{"apiKey":"sdk-secret","key":"dev","mobileKey":"mob-secret"}`,
+			wanted: `{"apiKey":"[REDACTED]","key":"dev","mobileKey":"[REDACTED]"}`,
+		},
+		{
+			name:   "nested in a project",
+			body:   `{"environments":[{"apiKey":"sdk-secret","key":"dev"}],"key":"project"}`,
+			wanted: `{"environments":[{"apiKey":"[REDACTED]","key":"dev"}],"key":"project"}`,
+		},
+		{
+			name:   "json patch",
+			body:   `[{"op":"replace","path":"/name","value":"name"}]`,
+			wanted: `[{"op":"replace","path":"/name","value":"name"}]`,
+		},
+		{
+			name:   "json patch of a secret",
+			body:   `[{"op":"replace","path":"/secret","value":"shh"}]`,
+			wanted: `[{"op":"replace","path":"/secret","value":"[REDACTED]"}]`,
+		},
+		{
+			name:   "not json",
+			body:   `<html>Bad Gateway</html>`,
+			wanted: `<html>Bad Gateway</html>`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if redacted := redactBody([]byte(testCase.body)); redacted != testCase.wanted {
+				t.Errorf("got (%s) but want (%s)", redacted, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestFormatHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "api-secret")
+	header.Set("Content-Type", "application/json")
+
+	wanted := "Authorization: [REDACTED]; Content-Type: application/json"
+	if formatted := formatHeaders(header); formatted != wanted {
+		t.Errorf("got (%s) but want (%s)", formatted, wanted)
+	}
+}
+
+func TestLogRequestPayloads(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	payload := []byte(`[{"op":"replace","path":"/on","value":true}]`)
+
+	logRequest("PATCH", "https://app.launchdarkly.com/api/v2/flags/p/f", http.Header{}, payload, false)
+	if strings.Contains(output.String(), "payload") {
+		t.Errorf("payload was logged while log_payloads is disabled: %s", output.String())
+	}
+
+	logRequest("PATCH", "https://app.launchdarkly.com/api/v2/flags/p/f", http.Header{}, payload, true)
+	if !strings.Contains(output.String(), string(payload)) {
+		t.Errorf("payload was not logged while log_payloads is enabled: %s", output.String())
+	}
+}
@@ -0,0 +1,126 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+const POLICY_EFFECT_ALLOW = "allow"
+const POLICY_EFFECT_DENY = "deny"
+
+// policyStatementsSchema is the schema of the policy statements used by LaunchDarkly to grant access to
+// resources, or to select the resources and actions a webhook is notified of. Statements are sets so that
+// reordering them does not show up as a change.
+func policyStatementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     policyStatementResource(),
+	}
+}
+
+func policyStatementResource() *schema.Resource {
+	resourcesSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validatePolicyResource,
+			},
+		}
+	}
+	actionsSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validatePolicyAction,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"effect": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{POLICY_EFFECT_ALLOW, POLICY_EFFECT_DENY}, false),
+			},
+			"resources":     resourcesSchema(),
+			"not_resources": resourcesSchema(),
+			"actions":       actionsSchema(),
+			"not_actions":   actionsSchema(),
+		},
+	}
+}
+
+func transformPolicyStatementsFromTerraformFormat(statements interface{}) ([]JsonStatement, error) {
+	transformed := make([]JsonStatement, 0)
+	for _, rawStatement := range getListOrSet(statements) {
+		statement := rawStatement.(map[string]interface{})
+		jsonStatement := JsonStatement{
+			Effect:       statement["effect"].(string),
+			Resources:    transformStringSetFromTerraformFormat(statement["resources"]),
+			NotResources: transformStringSetFromTerraformFormat(statement["not_resources"]),
+			Actions:      transformStringSetFromTerraformFormat(statement["actions"]),
+			NotActions:   transformStringSetFromTerraformFormat(statement["not_actions"]),
+		}
+
+		if err := validatePolicyStatement(statement); err != nil {
+			return nil, err
+		}
+		if len(jsonStatement.Resources) == 0 && len(jsonStatement.NotResources) == 0 {
+			return nil, fmt.Errorf("policy statement: one of resources or not_resources must be set")
+		}
+		if len(jsonStatement.Actions) == 0 && len(jsonStatement.NotActions) == 0 {
+			return nil, fmt.Errorf("policy statement: one of actions or not_actions must be set")
+		}
+
+		transformed = append(transformed, jsonStatement)
+	}
+	return transformed, nil
+}
+
+func transformPolicyStatementsFromLaunchDarklyFormat(statements []JsonStatement) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(statements))
+	for index, statement := range statements {
+		transformed[index] = map[string]interface{}{
+			"effect":        statement.Effect,
+			"resources":     statement.Resources,
+			"not_resources": statement.NotResources,
+			"actions":       statement.Actions,
+			"not_actions":   statement.NotActions,
+		}
+	}
+	return transformed
+}
+
+// validatePolicyStatements checks at plan time that the statements do not use both the positive and the
+// negated form of resources or actions. Statements using neither are rejected when applying, since
+// sets that are not known yet are empty during the plan.
+func validatePolicyStatements(statements interface{}) error {
+	for _, rawStatement := range getListOrSet(statements) {
+		statement, ok := rawStatement.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validatePolicyStatement(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePolicyStatement(statement map[string]interface{}) error {
+	if len(getListOrSet(statement["resources"])) > 0 && len(getListOrSet(statement["not_resources"])) > 0 {
+		return fmt.Errorf("policy statement: resources and not_resources cannot be set at the same time")
+	}
+	if len(getListOrSet(statement["actions"])) > 0 && len(getListOrSet(statement["not_actions"])) > 0 {
+		return fmt.Errorf("policy statement: actions and not_actions cannot be set at the same time")
+	}
+	return nil
+}
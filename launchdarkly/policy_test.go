@@ -0,0 +1,87 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestTransformPolicyStatementsFromTerraformFormat(t *testing.T) {
+	testCases := []struct {
+		name      string
+		statement map[string]interface{}
+		wanted    string
+		wantErr   bool
+	}{
+		{
+			name: "resources and actions",
+			statement: map[string]interface{}{
+				"effect":    "allow",
+				"resources": schema.NewSet(schema.HashString, []interface{}{"proj/*:env/staging:flag/*", "proj/*:env/dev:flag/*"}),
+				"actions":   []interface{}{"*"},
+			},
+			wanted: `[{"effect": "allow", "resources": ["proj/*:env/dev:flag/*", "proj/*:env/staging:flag/*"], "actions": ["*"]}]`,
+		},
+		{
+			name: "negated",
+			statement: map[string]interface{}{
+				"effect":        "deny",
+				"not_resources": []interface{}{"proj/*:env/dev:flag/*"},
+				"not_actions":   []interface{}{"updateOn"},
+			},
+			wanted: `[{"effect": "deny", "notResources": ["proj/*:env/dev:flag/*"], "notActions": ["updateOn"]}]`,
+		},
+		{
+			name: "both resources and not_resources",
+			statement: map[string]interface{}{
+				"effect":        "allow",
+				"resources":     []interface{}{"proj/*"},
+				"not_resources": []interface{}{"proj/secret"},
+				"actions":       []interface{}{"*"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no actions",
+			statement: map[string]interface{}{
+				"effect":    "allow",
+				"resources": []interface{}{"proj/*"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statements, err := transformPolicyStatementsFromTerraformFormat([]interface{}{testCase.statement})
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr {
+				testPayloadVerify(t, statements, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestPolicyStatementsOrderInsensitive(t *testing.T) {
+	first := map[string]interface{}{"effect": "allow", "resources": []interface{}{"proj/a", "proj/b"}, "actions": []interface{}{"*"}}
+	second := map[string]interface{}{"effect": "deny", "resources": []interface{}{"proj/c"}, "actions": []interface{}{"deleteProject"}}
+	reordered := map[string]interface{}{"effect": "allow", "resources": []interface{}{"proj/b", "proj/a"}, "actions": []interface{}{"*"}}
+
+	d1 := schema.TestResourceDataRaw(t, resourceCustomRole().Schema, map[string]interface{}{
+		"key":    "my-role",
+		"name":   "My role",
+		"policy": []interface{}{first, second},
+	})
+	d2 := schema.TestResourceDataRaw(t, resourceCustomRole().Schema, map[string]interface{}{
+		"key":    "my-role",
+		"name":   "My role",
+		"policy": []interface{}{second, reordered},
+	})
+
+	policy1, policy2 := d1.Get("policy").(*schema.Set), d2.Get("policy").(*schema.Set)
+	if policy1.Difference(policy2).Len() != 0 || policy2.Difference(policy1).Len() != 0 {
+		t.Errorf("expected the policies to be equal regardless of ordering: %v and %v", policy1.List(), policy2.List())
+	}
+}
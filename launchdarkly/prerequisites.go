@@ -0,0 +1,149 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func prerequisitesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"flag_key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateFeatureFlagKey,
+				},
+				"variation": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateVariationValue,
+				},
+			},
+		},
+	}
+}
+
+// getFlagsVariations fetches the variations of the flags referenced as prerequisites, since the index
+// of the prerequisite variation can only be resolved against them. Flags that do not exist are left
+// out of the result.
+func getFlagsVariations(ctx context.Context, client Client, project string, flagKeys []string) (map[string][]JsonVariations, error) {
+	flagsVariations := make(map[string][]JsonVariations)
+	for _, flagKey := range flagKeys {
+		if _, fetched := flagsVariations[flagKey]; fetched {
+			continue
+		}
+
+		var response JsonFeatureFlag
+		err := client.GetInto(ctx, client.getFlagUrl(project, flagKey), []int{200}, &response)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		flagsVariations[flagKey] = response.Variations
+	}
+	return flagsVariations, nil
+}
+
+func getPrerequisiteFlagKeysFromTerraformFormat(prerequisites []interface{}) []string {
+	flagKeys := make([]string, 0, len(prerequisites))
+	for _, rawPrerequisite := range prerequisites {
+		prerequisite, ok := rawPrerequisite.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		flagKey, _ := prerequisite["flag_key"].(string)
+		if len(flagKey) > 0 && flagKey != hcl2shim.UnknownVariableValue {
+			flagKeys = append(flagKeys, flagKey)
+		}
+	}
+	return flagKeys
+}
+
+func getPrerequisiteFlagKeysFromLaunchDarklyFormat(prerequisites []JsonPrerequisite) []string {
+	flagKeys := make([]string, len(prerequisites))
+	for index, prerequisite := range prerequisites {
+		flagKeys[index] = prerequisite.Key
+	}
+	return flagKeys
+}
+
+func transformPrerequisitesFromTerraformFormat(prerequisites []interface{}, flagsVariations map[string][]JsonVariations) ([]JsonPrerequisite, error) {
+	transformed := make([]JsonPrerequisite, len(prerequisites))
+	for index, rawPrerequisite := range prerequisites {
+		prerequisite := rawPrerequisite.(map[string]interface{})
+		flagKey := prerequisite["flag_key"].(string)
+
+		variations, found := flagsVariations[flagKey]
+		if !found {
+			return nil, fmt.Errorf("the prerequisite flag %s does not exist", flagKey)
+		}
+		variationIndex, err := getVariationIndex(getVariationsFromLaunchDarklyFormat(variations), prerequisite["variation"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("prerequisite flag %s: %s", flagKey, err)
+		}
+
+		transformed[index] = JsonPrerequisite{
+			Key:       flagKey,
+			Variation: variationIndex,
+		}
+	}
+	return transformed, nil
+}
+
+func transformPrerequisitesFromLaunchDarklyFormat(prerequisites []JsonPrerequisite, flagsVariations map[string][]JsonVariations) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(prerequisites))
+	for index, prerequisite := range prerequisites {
+		variationIndex := prerequisite.Variation
+		transformed[index] = map[string]interface{}{
+			"flag_key":  prerequisite.Key,
+			"variation": getVariationValue(flagsVariations[prerequisite.Key], &variationIndex),
+		}
+	}
+	return transformed
+}
+
+// validatePrerequisites checks at plan time that the prerequisite variations exist. Flags that are not
+// known yet, e.g. created in the same run, are checked when applying.
+func validatePrerequisites(prerequisites []interface{}, flagsVariations map[string][]JsonVariations) error {
+	for _, rawPrerequisite := range prerequisites {
+		prerequisite, ok := rawPrerequisite.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		flagKey, _ := prerequisite["flag_key"].(string)
+		variation, _ := prerequisite["variation"].(string)
+		variations, found := flagsVariations[flagKey]
+		if !found || variation == hcl2shim.UnknownVariableValue {
+			continue
+		}
+		if _, err := getVariationIndex(getVariationsFromLaunchDarklyFormat(variations), variation); err != nil {
+			return fmt.Errorf("prerequisite flag %s: %s", flagKey, err)
+		}
+	}
+	return nil
+}
+
+// validatePrerequisitesWithClient fetches the flags referenced by the prerequisites to validate them
+// during the plan. It is skipped when the provider is not configured yet.
+func validatePrerequisitesWithClient(m interface{}, project string, prerequisites []interface{}) error {
+	client, ok := m.(Client)
+	if !ok || len(prerequisites) == 0 || len(project) == 0 || project == hcl2shim.UnknownVariableValue {
+		return nil
+	}
+	ctx, cancel := client.newContext(defaultResourceTimeout)
+	defer cancel()
+
+	flagsVariations, err := getFlagsVariations(ctx, client, project, getPrerequisiteFlagKeysFromTerraformFormat(prerequisites))
+	if err != nil {
+		return err
+	}
+	return validatePrerequisites(prerequisites, flagsVariations)
+}
@@ -0,0 +1,80 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+)
+
+var testPrerequisiteFlags = map[string][]JsonVariations{
+	"prerequisite-flag": {{Value: true}, {Value: false}},
+}
+
+func TestTransformPrerequisitesFromTerraformFormat(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prerequisites []interface{}
+		wanted        string
+		wantErr       bool
+	}{
+		{
+			name: "existing variation",
+			prerequisites: []interface{}{
+				map[string]interface{}{"flag_key": "prerequisite-flag", "variation": "false"},
+			},
+			wanted: `[{"key": "prerequisite-flag", "variation": 1}]`,
+		},
+		{
+			name: "unknown variation",
+			prerequisites: []interface{}{
+				map[string]interface{}{"flag_key": "prerequisite-flag", "variation": "maybe"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown flag",
+			prerequisites: []interface{}{
+				map[string]interface{}{"flag_key": "missing-flag", "variation": "true"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			prerequisites, err := transformPrerequisitesFromTerraformFormat(testCase.prerequisites, testPrerequisiteFlags)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr {
+				testPayloadVerify(t, prerequisites, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestValidatePrerequisites(t *testing.T) {
+	testCases := []struct {
+		name      string
+		flagKey   string
+		variation string
+		wantErr   bool
+	}{
+		{name: "existing variation", flagKey: "prerequisite-flag", variation: "true"},
+		{name: "unknown variation", flagKey: "prerequisite-flag", variation: "maybe", wantErr: true},
+		{name: "variation not known yet", flagKey: "prerequisite-flag", variation: hcl2shim.UnknownVariableValue},
+		{name: "flag that does not exist yet", flagKey: "new-flag", variation: "maybe"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			prerequisites := []interface{}{map[string]interface{}{
+				"flag_key":  testCase.flagKey,
+				"variation": testCase.variation,
+			}}
+			if err := validatePrerequisites(prerequisites, testPrerequisiteFlags); (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
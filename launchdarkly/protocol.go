@@ -1,11 +1,26 @@
 package launchdarkly
 
 type JsonEnvironment struct {
-	Name      string `json:"name"`
-	Key       string `json:"key"`
-	Color     string `json:"color"`
-	ApiKey    string `json:"apiKey"`
-	MobileKey string `json:"mobileKey"`
+	Name               string                `json:"name"`
+	Key                string                `json:"key"`
+	Color              string                `json:"color"`
+	ApiKey             string                `json:"apiKey"`
+	MobileKey          string                `json:"mobileKey"`
+	SecureMode         bool                  `json:"secureMode"`
+	DefaultTtl         int                   `json:"defaultTtl"`
+	DefaultTrackEvents bool                  `json:"defaultTrackEvents"`
+	RequireComments    bool                  `json:"requireComments"`
+	ConfirmChanges     bool                  `json:"confirmChanges"`
+	Tags               []string              `json:"tags,omitempty"`
+	ApprovalSettings   *JsonApprovalSettings `json:"approvalSettings,omitempty"`
+}
+
+type JsonApprovalSettings struct {
+	Required                bool     `json:"required"`
+	CanReviewOwnRequest     bool     `json:"canReviewOwnRequest"`
+	MinNumApprovals         int      `json:"minNumApprovals"`
+	CanApplyDeclinedChanges bool     `json:"canApplyDeclinedChanges"`
+	RequiredApprovalTags    []string `json:"requiredApprovalTags"`
 }
 
 type JsonProject struct {
@@ -30,14 +45,156 @@ type JsonCustomProperty struct {
 	Value []string `json:"value"`
 }
 
+type JsonWeightedVariation struct {
+	Variation int `json:"variation"`
+	Weight    int `json:"weight"`
+}
+
+type JsonRollout struct {
+	Variations []JsonWeightedVariation `json:"variations"`
+	BucketBy   string                  `json:"bucketBy,omitempty"`
+}
+
+type JsonFallthrough struct {
+	Variation *int         `json:"variation,omitempty"`
+	Rollout   *JsonRollout `json:"rollout,omitempty"`
+}
+
+type JsonClause struct {
+	Attribute string        `json:"attribute"`
+	Op        string        `json:"op"`
+	Values    []interface{} `json:"values"`
+	Negate    bool          `json:"negate"`
+}
+
+type JsonRule struct {
+	Variation *int         `json:"variation,omitempty"`
+	Rollout   *JsonRollout `json:"rollout,omitempty"`
+	Clauses   []JsonClause `json:"clauses"`
+}
+
+type JsonTarget struct {
+	Values    []string `json:"values"`
+	Variation int      `json:"variation"`
+}
+
+type JsonPrerequisite struct {
+	Key       string `json:"key"`
+	Variation int    `json:"variation"`
+}
+
+type JsonFlagEnvironment struct {
+	On            bool               `json:"on"`
+	OffVariation  *int               `json:"offVariation"`
+	Fallthrough   JsonFallthrough    `json:"fallthrough"`
+	TrackEvents   bool               `json:"trackEvents"`
+	Rules         []JsonRule         `json:"rules"`
+	Targets       []JsonTarget       `json:"targets"`
+	Prerequisites []JsonPrerequisite `json:"prerequisites"`
+}
+
 type JsonFeatureFlag struct {
-	Name             string                        `json:"name"`
-	Key              string                        `json:"key"`
-	Description      string                        `json:"description"`
-	Temporary        bool                          `json:"temporary"`
-	IncludeInSnippet bool                          `json:"includeInSnippet"`
-	VariationsKind   string                        `json:"kind"`
-	Variations       []JsonVariations              `json:"variations"`
-	Tags             []string                      `json:"tags"`
-	CustomProperties map[string]JsonCustomProperty `json:"customProperties"`
+	Name             string                         `json:"name"`
+	Key              string                         `json:"key"`
+	Description      string                         `json:"description"`
+	Temporary        bool                           `json:"temporary"`
+	IncludeInSnippet bool                           `json:"includeInSnippet"`
+	VariationsKind   string                         `json:"kind"`
+	Variations       []JsonVariations               `json:"variations"`
+	Tags             []string                       `json:"tags"`
+	CustomProperties map[string]JsonCustomProperty  `json:"customProperties"`
+	Environments     map[string]JsonFlagEnvironment `json:"environments,omitempty"`
+}
+
+type JsonSegmentRule struct {
+	Clauses []JsonClause `json:"clauses"`
+}
+
+type JsonSegment struct {
+	Name        string            `json:"name"`
+	Key         string            `json:"key"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Included    []string          `json:"included,omitempty"`
+	Excluded    []string          `json:"excluded,omitempty"`
+	Rules       []JsonSegmentRule `json:"rules,omitempty"`
+}
+
+type JsonStatement struct {
+	Effect       string   `json:"effect"`
+	Resources    []string `json:"resources,omitempty"`
+	NotResources []string `json:"notResources,omitempty"`
+	Actions      []string `json:"actions,omitempty"`
+	NotActions   []string `json:"notActions,omitempty"`
+}
+
+type JsonWebhook struct {
+	Id         string          `json:"_id,omitempty"`
+	Url        string          `json:"url"`
+	Name       string          `json:"name"`
+	Secret     string          `json:"secret,omitempty"`
+	Sign       bool            `json:"sign"`
+	On         bool            `json:"on"`
+	Tags       []string        `json:"tags"`
+	Statements []JsonStatement `json:"statements,omitempty"`
+}
+
+type JsonCustomRole struct {
+	Key         string          `json:"key"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Policy      []JsonStatement `json:"policy"`
+}
+
+type JsonMember struct {
+	Id          string   `json:"_id,omitempty"`
+	Email       string   `json:"email"`
+	FirstName   string   `json:"firstName,omitempty"`
+	LastName    string   `json:"lastName,omitempty"`
+	Role        string   `json:"role,omitempty"`
+	CustomRoles []string `json:"customRoles,omitempty"`
+}
+
+type JsonMembers struct {
+	Items      []JsonMember `json:"items"`
+	TotalCount int          `json:"totalCount"`
+}
+
+type JsonAccessToken struct {
+	Id                string          `json:"_id,omitempty"`
+	Name              string          `json:"name"`
+	Role              string          `json:"role,omitempty"`
+	InlineRole        []JsonStatement `json:"inlineRole,omitempty"`
+	ServiceToken      bool            `json:"serviceToken"`
+	DefaultApiVersion int             `json:"defaultApiVersion,omitempty"`
+	Token             string          `json:"token,omitempty"`
+}
+
+type JsonDestination struct {
+	Id     string                 `json:"_id,omitempty"`
+	Name   string                 `json:"name"`
+	Kind   string                 `json:"kind"`
+	Config map[string]interface{} `json:"config"`
+	On     bool                   `json:"on"`
+}
+
+type JsonMetricUrl struct {
+	Kind      string `json:"kind"`
+	Url       string `json:"url,omitempty"`
+	Substring string `json:"substring,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+}
+
+type JsonMetric struct {
+	Key             string          `json:"key"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	Kind            string          `json:"kind"`
+	Tags            []string        `json:"tags"`
+	Selector        string          `json:"selector,omitempty"`
+	Urls            []JsonMetricUrl `json:"urls,omitempty"`
+	EventKey        string          `json:"eventKey,omitempty"`
+	IsNumeric       bool            `json:"isNumeric"`
+	Unit            string          `json:"unit,omitempty"`
+	SuccessCriteria string          `json:"successCriteria,omitempty"`
 }
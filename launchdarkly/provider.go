@@ -1,40 +1,224 @@
 package launchdarkly
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
+			// The two attributes do not conflict since their environment variables are filled in before
+			// validation, getAccessToken picks the one to use instead
 			"access_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LAUNCHDARKLY_ACCESS_TOKEN", nil),
 				Description: "The access token used to authenticate against LaunchDarkly's API",
 				Sensitive:   true,
 			},
+			"access_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LAUNCHDARKLY_ACCESS_TOKEN_FILE", nil),
+				Description: "The path of a file containing the access token used to authenticate against LaunchDarkly's API, takes precedence over access_token",
+			},
+			"api_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LAUNCHDARKLY_API_HOST", defaultApiHost),
+				Description:  "The base URL of LaunchDarkly's API (e.g. for federal or EU instances, a relay or a proxy)",
+				ValidateFunc: validateApiHost,
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultApiVersion,
+				Description:  "The version of LaunchDarkly's API sent in the LD-API-Version header of every request",
+				ValidateFunc: validateApiVersion,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				Description:  "The maximum number of times a rate limited or failed request is retried",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_retry_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultMaxRetryWait / time.Second),
+				Description:  "The maximum number of seconds to wait between two retries of a request",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultRequestsPerSecond,
+				Description:  "The maximum number of requests per second sent to LaunchDarkly's API by all resources combined, 0 disables the limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"http_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultHttpTimeout / time.Second),
+				Description:  "The number of seconds after which a single request to LaunchDarkly's API is abandoned",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The URL of the proxy used to reach LaunchDarkly's API, the HTTPS_PROXY environment variable is used otherwise",
+				ValidateFunc: validateProxyUrl,
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path of a PEM file with additional certificate authorities to trust, e.g. for an intercepting proxy",
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded additional certificate authorities to trust, e.g. for an intercepting proxy",
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the verification of the server's TLS certificate. Only meant for local testing",
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path of a PEM encoded client certificate for mutual TLS",
+				ConflictsWith: []string{"client_cert_pem"},
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "A PEM encoded client certificate for mutual TLS",
+				ConflictsWith: []string{"client_cert_file"},
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path of the PEM encoded private key of the client certificate",
+				ConflictsWith: []string{"client_key_pem"},
+			},
+			"client_key_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "The PEM encoded private key of the client certificate",
+				ConflictsWith: []string{"client_key_file"},
+			},
+			"log_payloads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Log the payloads sent to LaunchDarkly's API (such as JSON patches) at the DEBUG level, with secrets redacted",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"launchdarkly_project":      resourceProject(),
-			"launchdarkly_environment":  resourceEnvironment(),
-			"launchdarkly_feature_flag": resourceFeatureFlag(),
+			"launchdarkly_project":                  resourceProject(),
+			"launchdarkly_environment":              resourceEnvironment(),
+			"launchdarkly_feature_flag":             resourceFeatureFlag(),
+			"launchdarkly_feature_flag_environment": resourceFeatureFlagEnvironment(),
+			"launchdarkly_segment":                  resourceSegment(),
+			"launchdarkly_webhook":                  resourceWebhook(),
+			"launchdarkly_custom_role":              resourceCustomRole(),
+			"launchdarkly_team_member":              resourceTeamMember(),
+			"launchdarkly_access_token":             resourceAccessToken(),
+			"launchdarkly_destination":              resourceDestination(),
+			"launchdarkly_metric":                   resourceMetric(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"launchdarkly_project":      dataSourceProject(),
 			"launchdarkly_environment":  dataSourceEnvironment(),
 			"launchdarkly_feature_flag": dataSourceFeatureFlag(),
+			"launchdarkly_segment":      dataSourceSegment(),
+			"launchdarkly_team_member":  dataSourceTeamMember(),
+			"launchdarkly_metric":       dataSourceMetric(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.StopContext(), provider.TerraformVersion)
 	}
 
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, stopContext context.Context, terraformVersion string) (interface{}, error) {
+	accessToken, err := getAccessToken(d.Get("access_token").(string), d.Get("access_token_file").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHttpClient(transportConfig{
+		Timeout:            time.Duration(d.Get("http_timeout").(int)) * time.Second,
+		ProxyUrl:           d.Get("proxy_url").(string),
+		CaCertFile:         d.Get("ca_cert_file").(string),
+		CaCertPem:          d.Get("ca_cert_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		ClientCertFile:     d.Get("client_cert_file").(string),
+		ClientCertPem:      d.Get("client_cert_pem").(string),
+		ClientKeyFile:      d.Get("client_key_file").(string),
+		ClientKeyPem:       d.Get("client_key_pem").(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetryWait := time.Duration(d.Get("max_retry_wait").(int)) * time.Second
+
 	client := Client{
-		AccessToken: d.Get("access_token").(string),
+		AccessToken:  accessToken,
+		ApiHost:      strings.TrimSuffix(d.Get("api_host").(string), "/"),
+		MaxRetries:   d.Get("max_retries").(int),
+		MaxRetryWait: maxRetryWait,
+		LogPayloads:  d.Get("log_payloads").(bool),
+		UserAgent:    getUserAgent(terraformVersion),
+		ApiVersion:   d.Get("api_version").(string),
+		httpClient:   httpClient,
+		rateLimiter:  newRateLimiter(float64(d.Get("requests_per_second").(int)), maxRetryWait),
+		stopContext:  stopContext,
 	}
 
 	return client, nil
 }
+
+// The token file takes precedence since it can only be set explicitly or through its own environment
+// variable, while access_token may come from a LAUNCHDARKLY_ACCESS_TOKEN variable set globally.
+func getAccessToken(accessToken string, accessTokenFile string) (string, error) {
+	if len(accessTokenFile) > 0 {
+		content, err := ioutil.ReadFile(accessTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read the LaunchDarkly access token from access_token_file: %s", err)
+		}
+
+		accessToken = strings.TrimSpace(string(content))
+		if len(accessToken) == 0 {
+			return "", fmt.Errorf("the access_token_file %s is empty", accessTokenFile)
+		}
+
+		return accessToken, nil
+	}
+
+	if len(accessToken) == 0 {
+		return "", errors.New("a LaunchDarkly access token must be provided through access_token, access_token_file, " +
+			"or the LAUNCHDARKLY_ACCESS_TOKEN or LAUNCHDARKLY_ACCESS_TOKEN_FILE environment variables")
+	}
+
+	return accessToken, nil
+}
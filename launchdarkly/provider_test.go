@@ -0,0 +1,112 @@
+package launchdarkly
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestGetAccessToken(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "launchdarkly-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+	tokenFile.WriteString("api-from-file\n")
+	tokenFile.Close()
+
+	emptyFile, err := ioutil.TempFile("", "launchdarkly-empty-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(emptyFile.Name())
+	emptyFile.Close()
+
+	testCases := []struct {
+		name            string
+		accessToken     string
+		accessTokenFile string
+		wantedToken     string
+		wantErr         bool
+	}{
+		{
+			name:        "with access token",
+			accessToken: "api-token",
+			wantedToken: "api-token",
+		},
+		{
+			name:            "with access token file",
+			accessTokenFile: tokenFile.Name(),
+			wantedToken:     "api-from-file",
+		},
+		{
+			name:            "token file takes precedence",
+			accessToken:     "api-from-environment",
+			accessTokenFile: tokenFile.Name(),
+			wantedToken:     "api-from-file",
+		},
+		{
+			name:            "with missing token file",
+			accessTokenFile: tokenFile.Name() + "-missing",
+			wantErr:         true,
+		},
+		{
+			name:            "with empty token file",
+			accessTokenFile: emptyFile.Name(),
+			wantErr:         true,
+		},
+		{
+			name:    "without any token",
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			token, err := getAccessToken(testCase.accessToken, testCase.accessTokenFile)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if token != testCase.wantedToken {
+				t.Errorf("got token (%s) but want (%s)", token, testCase.wantedToken)
+			}
+		})
+	}
+}
+
+func TestProviderAccessTokenFileWithEnvironmentToken(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "launchdarkly-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+	tokenFile.WriteString("api-from-file\n")
+	tokenFile.Close()
+
+	// A token exported globally must not prevent a configuration from using a token file
+	os.Setenv("LAUNCHDARKLY_ACCESS_TOKEN", "api-from-environment")
+	defer os.Unsetenv("LAUNCHDARKLY_ACCESS_TOKEN")
+
+	provider := Provider()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"access_token_file": tokenFile.Name(),
+	})
+
+	if _, errs := provider.Validate(config); len(errs) > 0 {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+	if err := provider.Configure(config); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if token := provider.Meta().(Client).AccessToken; token != "api-from-file" {
+		t.Errorf("got token (%s) but want (api-from-file)", token)
+	}
+}
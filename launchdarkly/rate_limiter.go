@@ -0,0 +1,147 @@
+package launchdarkly
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultRequestsPerSecond = 10
+
+// The rate is never lowered below this value when adapting to the remaining requests reported by
+// LaunchDarkly, so that a stale header cannot stall every operation.
+const minimumRequestsPerSecond = 0.1
+
+// Terraform runs resource operations concurrently and LaunchDarkly applies its rate limits to the
+// access token, so every request sent by the provider goes through a single token bucket. The rate
+// is lowered when LaunchDarkly reports that few requests remain in the current window, and requests
+// are paused until the window resets when none remain.
+type rateLimiter struct {
+	mutex       sync.Mutex
+	rate        float64
+	currentRate float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	maxPause    time.Duration
+}
+
+// Requests are paused for at most maxPause when no request remains, like the waits between retries.
+func newRateLimiter(requestsPerSecond float64, maxPause time.Duration) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := math.Max(1, requestsPerSecond)
+	return &rateLimiter{
+		rate:        requestsPerSecond,
+		currentRate: requestsPerSecond,
+		burst:       burst,
+		tokens:      burst,
+		maxPause:    maxPause,
+	}
+}
+
+// Wait blocks until the request can be sent or the context is done. A nil limiter never blocks.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	wait := l.reserve(time.Now())
+	if wait > 0 {
+		log.Printf("[TRACE] Waiting %s before sending a request to stay under the LaunchDarkly rate limit", wait)
+	}
+	return sleep(ctx, wait)
+}
+
+// reserve takes a token from the bucket and returns how long to wait before it becomes valid. Tokens
+// may go negative so that concurrent callers queue up instead of all waking up at the same time.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.currentRate)
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.currentRate * float64(time.Second))
+	}
+	if paused := l.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+
+	return wait
+}
+
+// Observe adapts the rate to the X-Ratelimit-Remaining and X-Ratelimit-Reset headers of a response.
+func (l *rateLimiter) Observe(header http.Header) {
+	if l == nil {
+		return
+	}
+
+	l.observe(header, time.Now())
+}
+
+func (l *rateLimiter) observe(header http.Header, now time.Time) {
+	remaining, found := getRemainingRequests(header)
+	if !found {
+		return
+	}
+
+	resetMilliseconds, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	reset := time.Unix(0, resetMilliseconds*int64(time.Millisecond))
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !reset.After(now) {
+		l.currentRate = l.rate
+		return
+	}
+
+	if remaining <= 0 {
+		// A skewed clock or a bogus header must not stall every operation until it times out
+		pausedUntil := reset
+		if maxPausedUntil := now.Add(l.maxPause); pausedUntil.After(maxPausedUntil) {
+			pausedUntil = maxPausedUntil
+		}
+		if pausedUntil.After(l.pausedUntil) {
+			log.Printf("[DEBUG] LaunchDarkly rate limit reached, pausing requests until %s", pausedUntil.Format(time.RFC3339))
+			l.pausedUntil = pausedUntil
+		}
+		return
+	}
+
+	windowRate := float64(remaining) / reset.Sub(now).Seconds()
+	l.currentRate = math.Max(minimumRequestsPerSecond, math.Min(l.rate, windowRate))
+}
+
+// LaunchDarkly reports both a global and a per route limit, we follow whichever is closest to be hit.
+func getRemainingRequests(header http.Header) (int, bool) {
+	remaining := 0
+	found := false
+	for _, name := range []string{"X-Ratelimit-Remaining", "X-Ratelimit-Global-Remaining", "X-Ratelimit-Route-Remaining"} {
+		value, err := strconv.Atoi(header.Get(name))
+		if err != nil {
+			continue
+		}
+		if !found || value < remaining {
+			remaining = value
+			found = true
+		}
+	}
+	return remaining, found
+}
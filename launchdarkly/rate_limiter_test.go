@@ -0,0 +1,114 @@
+package launchdarkly
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func epochMilliseconds(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+func TestNewRateLimiterDisabled(t *testing.T) {
+	if limiter := newRateLimiter(0, time.Minute); limiter != nil {
+		t.Errorf("expected a nil limiter when the rate is 0")
+	}
+
+	// A nil limiter must be usable by a client that was configured without one
+	var limiter *rateLimiter
+	limiter.Wait(context.Background())
+	limiter.Observe(http.Header{"X-Ratelimit-Remaining": []string{"0"}})
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2, time.Minute)
+
+	// The burst allows as many requests as the rate right away, then requests are spaced out
+	waits := []time.Duration{
+		limiter.reserve(now),
+		limiter.reserve(now),
+		limiter.reserve(now),
+		limiter.reserve(now),
+	}
+	wanted := []time.Duration{0, 0, 500 * time.Millisecond, time.Second}
+	for index := range wanted {
+		if waits[index] != wanted[index] {
+			t.Errorf("request %d: got wait (%s) but want (%s)", index, waits[index], wanted[index])
+		}
+	}
+
+	// Tokens are refilled over time
+	if wait := limiter.reserve(now.Add(2 * time.Second)); wait != 0 {
+		t.Errorf("got wait (%s) after refill but want none", wait)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		header     http.Header
+		wantedRate float64
+		wantedWait time.Duration
+	}{
+		{
+			name:       "without headers",
+			header:     http.Header{},
+			wantedRate: 10,
+		},
+		{
+			name: "with plenty of remaining requests",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"1000"},
+				"X-Ratelimit-Reset":     []string{epochMilliseconds(now.Add(10 * time.Second))},
+			},
+			wantedRate: 10,
+		},
+		{
+			name: "with few remaining requests",
+			header: http.Header{
+				"X-Ratelimit-Global-Remaining": []string{"100"},
+				"X-Ratelimit-Route-Remaining":  []string{"20"},
+				"X-Ratelimit-Reset":            []string{epochMilliseconds(now.Add(10 * time.Second))},
+			},
+			wantedRate: 2,
+		},
+		{
+			name: "without remaining requests",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{epochMilliseconds(now.Add(3 * time.Second))},
+			},
+			wantedRate: 10,
+			wantedWait: 3 * time.Second,
+		},
+		{
+			name: "with a distant reset",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{epochMilliseconds(now.Add(time.Hour))},
+			},
+			wantedRate: 10,
+			wantedWait: time.Minute,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			limiter := newRateLimiter(10, time.Minute)
+			limiter.observe(testCase.header, now)
+
+			if limiter.currentRate != testCase.wantedRate {
+				t.Errorf("got rate (%v) but want (%v)", limiter.currentRate, testCase.wantedRate)
+			}
+			if wait := limiter.reserve(now); wait != testCase.wantedWait {
+				t.Errorf("got wait (%s) but want (%s)", wait, testCase.wantedWait)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceAccessToken() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceAccessTokenCreate,
+		Read:          resourceAccessTokenRead,
+		Update:        resourceAccessTokenUpdate,
+		Delete:        resourceAccessTokenDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceAccessTokenCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringInSlice(memberRoles, false),
+				ConflictsWith: []string{"policy_statements"},
+			},
+			"policy_statements": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          policyStatementResource(),
+				ConflictsWith: []string{"role"},
+			},
+			"service_token": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"default_api_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			// Changing rotate_trigger resets the token, e.g. a date to rotate the token when it changes
+			"rotate_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The previous secret of a reset token expires at this Unix time in milliseconds, or immediately
+			// when it is not positive
+			"expire": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// LaunchDarkly only returns the secret when the token is created or reset
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceAccessTokenCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange("rotate_trigger") && len(d.Id()) > 0 {
+		if err := d.SetNewComputed("token"); err != nil {
+			return err
+		}
+	}
+	return validatePolicyStatements(d.Get("policy_statements"))
+}
+
+func resourceAccessTokenCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	role := d.Get("role").(string)
+	inlineRole, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy_statements"))
+	if err != nil {
+		return err
+	}
+	if len(role) == 0 && len(inlineRole) == 0 {
+		return fmt.Errorf("one of role or policy_statements must be set")
+	}
+
+	payload := JsonAccessToken{
+		Name:              d.Get("name").(string),
+		Role:              role,
+		InlineRole:        inlineRole,
+		ServiceToken:      d.Get("service_token").(bool),
+		DefaultApiVersion: d.Get("default_api_version").(int),
+	}
+
+	var response JsonAccessToken
+	err = client.Post(ctx, client.getAccessTokenCreateUrl(), payload, []int{201}, &response)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(response.Id)
+	d.Set("token", response.Token)
+
+	return resourceAccessTokenRead(d, m)
+}
+
+func resourceAccessTokenRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonAccessToken
+	err := client.GetInto(ctx, client.getAccessTokenUrl(d.Id()), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", response.Name)
+	d.Set("role", response.Role)
+	d.Set("service_token", response.ServiceToken)
+	d.Set("default_api_version", response.DefaultApiVersion)
+	if err := d.Set("policy_statements", transformPolicyStatementsFromLaunchDarklyFormat(response.InlineRole)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAccessTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	role := d.Get("role").(string)
+	inlineRole, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy_statements"))
+	if err != nil {
+		return err
+	}
+	if len(role) == 0 && len(inlineRole) == 0 {
+		return fmt.Errorf("one of role or policy_statements must be set")
+	}
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}}
+	// A token has either a role or an inline role, the one that is no longer used must be removed
+	oldRole, _ := d.GetChange("role")
+	oldStatements, _ := d.GetChange("policy_statements")
+	if len(role) > 0 {
+		payload = append(payload, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/role",
+			"value": role,
+		})
+		if len(getListOrSet(oldStatements)) > 0 {
+			payload = append(payload, map[string]interface{}{
+				"op":   "remove",
+				"path": "/inlineRole",
+			})
+		}
+	} else {
+		payload = append(payload, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/inlineRole",
+			"value": inlineRole,
+		})
+		if len(oldRole.(string)) > 0 {
+			payload = append(payload, map[string]interface{}{
+				"op":   "remove",
+				"path": "/role",
+			})
+		}
+	}
+
+	// The new rotate_trigger is only saved once the token is reset, so that a failed reset is retried by
+	// the next apply rather than forgotten
+	d.Partial(true)
+
+	_, err = client.Patch(ctx, client.getAccessTokenUrl(d.Id()), payload, []int{200})
+	if err != nil {
+		return err
+	}
+	d.SetPartial("name")
+	d.SetPartial("role")
+	d.SetPartial("policy_statements")
+	d.SetPartial("expire")
+
+	if d.HasChange("rotate_trigger") {
+		var response JsonAccessToken
+		err = client.Post(ctx, client.getAccessTokenResetUrl(d.Id(), d.Get("expire").(int)), map[string]interface{}{}, []int{200}, &response)
+		if err != nil {
+			return err
+		}
+		d.Set("token", response.Token)
+		d.SetPartial("token")
+		d.SetPartial("rotate_trigger")
+	}
+
+	d.Partial(false)
+
+	return resourceAccessTokenRead(d, m)
+}
+
+func resourceAccessTokenDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	err := client.Delete(ctx, client.getAccessTokenUrl(d.Id()), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
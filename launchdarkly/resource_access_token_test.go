@@ -0,0 +1,217 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// newTestAccessTokenServer only returns the secret when the token is created or reset, like LaunchDarkly.
+func newTestAccessTokenServer(t *testing.T) (*httptest.Server, *map[string]interface{}, *string) {
+	resetUrl := ""
+	server, document := newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/tokens",
+		path:       "/api/v2/tokens/token-id",
+		id:         "token-id",
+		respond: func(r *http.Request, response map[string]interface{}) map[string]interface{} {
+			if r.Method == "POST" {
+				response["token"] = "api-first"
+			}
+			return response
+		},
+		handle: func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/api/v2/tokens/token-id/reset" {
+				w.WriteHeader(404)
+				return
+			}
+			resetUrl = r.URL.String()
+			w.WriteHeader(200)
+			w.Write([]byte(`{"_id": "token-id", "token": "api-second"}`))
+		},
+	})
+	return server, document, &resetUrl
+}
+
+func TestResourceAccessTokenCreate(t *testing.T) {
+	server, document, _ := newTestAccessTokenServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceAccessToken().Schema, map[string]interface{}{
+		"name":                "CI",
+		"role":                "writer",
+		"service_token":       true,
+		"default_api_version": 20191212,
+	})
+
+	if err := resourceAccessTokenCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "token-id" || d.Get("token") != "api-first" {
+		t.Errorf("the token was not created as expected: %v", d.State())
+	}
+	if (*document)["serviceToken"] != true || (*document)["defaultApiVersion"] != float64(20191212) {
+		t.Errorf("the token was not sent as expected: %v", *document)
+	}
+}
+
+func TestResourceAccessTokenCreateWithoutRole(t *testing.T) {
+	server, _, _ := newTestAccessTokenServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceAccessToken().Schema, map[string]interface{}{
+		"name": "CI",
+	})
+
+	if err := resourceAccessTokenCreate(d, newTestClient(server)); err == nil {
+		t.Error("expected an error for a token without a role nor policy statements")
+	}
+}
+
+func TestResourceAccessTokenRotate(t *testing.T) {
+	server, _, resetUrl := newTestAccessTokenServer(t)
+	defer server.Close()
+
+	resource := resourceAccessToken()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "CI",
+		"role": "reader",
+	})
+	if err := resourceAccessTokenCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Changing rotate_trigger resets the token, the previous secret expiring at expire
+	state := d.State()
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "CI",
+		"role":           "reader",
+		"rotate_trigger": "2020-01",
+		"expire":         1580000000000,
+	}), newTestClient(server))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !diff.Attributes["token"].NewComputed {
+		t.Errorf("expected the token to be recomputed: %v", diff)
+	}
+
+	updated, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := resourceAccessTokenUpdate(updated, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if *resetUrl != "/api/v2/tokens/token-id/reset?expiry=1580000000000" {
+		t.Errorf("the token was not reset as expected: %s", *resetUrl)
+	}
+	if updated.Get("token") != "api-second" {
+		t.Errorf("got token (%s) but want (api-second)", updated.Get("token"))
+	}
+}
+
+func TestResourceAccessTokenRotateFailure(t *testing.T) {
+	server, _ := newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/tokens",
+		path:       "/api/v2/tokens/token-id",
+		id:         "token-id",
+		handle: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(403)
+		},
+	})
+	defer server.Close()
+
+	resource := resourceAccessToken()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":           "CI",
+		"role":           "reader",
+		"rotate_trigger": "2020-01",
+	})
+	if err := resourceAccessTokenCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state := d.State()
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "CI",
+		"role":           "reader",
+		"rotate_trigger": "2020-02",
+	}), newTestClient(server))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	updated, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := resourceAccessTokenUpdate(updated, newTestClient(server)); err == nil {
+		t.Fatal("expected an error when the token cannot be reset")
+	}
+
+	// The previous trigger is kept so that the next plan rotates the token again
+	if trigger := updated.State().Attributes["rotate_trigger"]; trigger != "2020-01" {
+		t.Errorf("got rotate_trigger (%s) but want (2020-01)", trigger)
+	}
+}
+
+func TestResourceAccessTokenUpdateRole(t *testing.T) {
+	server, document, _ := newTestAccessTokenServer(t)
+	defer server.Close()
+
+	resource := resourceAccessToken()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name": "CI",
+		"role": "writer",
+	})
+	if err := resourceAccessTokenCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	update := func(config map[string]interface{}) {
+		state := d.State()
+		diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(config), newTestClient(server))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		d, err = schema.InternalMap(resource.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if err := resourceAccessTokenUpdate(d, newTestClient(server)); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	// From a role to policy statements
+	update(map[string]interface{}{
+		"name": "CI",
+		"policy_statements": []interface{}{map[string]interface{}{
+			"effect":    "allow",
+			"resources": []interface{}{"proj/*:env/production:flag/*"},
+			"actions":   []interface{}{"updateOn"},
+		}},
+	})
+	if _, found := (*document)["role"]; found {
+		t.Errorf("the role was not removed: %v", *document)
+	}
+	if d.Get("role") != "" || d.Get("policy_statements").(*schema.Set).Len() != 1 {
+		t.Errorf("the token was not read back as expected: %v", d.State())
+	}
+
+	// And back to a role
+	update(map[string]interface{}{
+		"name": "CI",
+		"role": "reader",
+	})
+	if _, found := (*document)["inlineRole"]; found {
+		t.Errorf("the inline role was not removed: %v", *document)
+	}
+	if d.Get("role") != "reader" || d.Get("policy_statements").(*schema.Set).Len() != 0 {
+		t.Errorf("the token was not read back as expected: %v", d.State())
+	}
+}
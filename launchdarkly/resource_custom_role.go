@@ -0,0 +1,155 @@
+package launchdarkly
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceCustomRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCustomRoleCreate,
+		Read:   resourceCustomRoleRead,
+		Update: resourceCustomRoleUpdate,
+		Delete: resourceCustomRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCustomRoleImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceCustomRoleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policy": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     policyStatementResource(),
+			},
+		},
+	}
+}
+
+func resourceCustomRoleCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	return validatePolicyStatements(d.Get("policy"))
+}
+
+func resourceCustomRoleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("key", d.Id())
+
+	if err := resourceCustomRoleRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCustomRoleCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	key := d.Get("key").(string)
+
+	policy, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy"))
+	if err != nil {
+		return err
+	}
+
+	payload := JsonCustomRole{
+		Key:         key,
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Policy:      policy,
+	}
+
+	err = client.Post(ctx, client.getCustomRoleCreateUrl(), payload, []int{201}, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(key)
+
+	return resourceCustomRoleRead(d, m)
+}
+
+func resourceCustomRoleRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonCustomRole
+	err := client.GetInto(ctx, client.getCustomRoleUrl(d.Id()), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("key", response.Key)
+	d.Set("name", response.Name)
+	d.Set("description", response.Description)
+	if err := d.Set("policy", transformPolicyStatementsFromLaunchDarklyFormat(response.Policy)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCustomRoleUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	policy, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy"))
+	if err != nil {
+		return err
+	}
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/description",
+		"value": d.Get("description").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/policy",
+		"value": policy,
+	}}
+
+	_, err = client.Patch(ctx, client.getCustomRoleUrl(d.Id()), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceCustomRoleRead(d, m)
+}
+
+func resourceCustomRoleDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	err := client.Delete(ctx, client.getCustomRoleUrl(d.Id()), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
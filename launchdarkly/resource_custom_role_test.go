@@ -0,0 +1,43 @@
+package launchdarkly
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceCustomRoleCreate(t *testing.T) {
+	server, document := newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/roles",
+		path:       "/api/v2/roles/flag-maintainers",
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceCustomRole().Schema, map[string]interface{}{
+		"key":  "flag-maintainers",
+		"name": "Flag maintainers",
+		"policy": []interface{}{map[string]interface{}{
+			"effect":    "allow",
+			"resources": []interface{}{"proj/*:env/production:flag/*"},
+			"actions":   []interface{}{"updateOn", "updateFallthrough"},
+		}},
+	})
+
+	if err := resourceCustomRoleCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "flag-maintainers" {
+		t.Errorf("got id (%s) but want (flag-maintainers)", d.Id())
+	}
+	testPayloadVerify(t, (*document)["policy"], `[{
+		"effect": "allow",
+		"resources": ["proj/*:env/production:flag/*"],
+		"actions": ["updateFallthrough", "updateOn"]
+	}]`)
+
+	policy := d.Get("policy").(*schema.Set).List()
+	if len(policy) != 1 || policy[0].(map[string]interface{})["actions"].(*schema.Set).Len() != 2 {
+		t.Errorf("the policy was not read back as expected: %v", policy)
+	}
+}
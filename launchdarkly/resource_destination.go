@@ -0,0 +1,337 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// destinationKind describes how the configuration block of a kind of destination maps to the config
+// object of LaunchDarkly's API.
+type destinationKind struct {
+	kind string
+	// Attributes of the block by their key in the config object
+	fields map[string]string
+	// Attributes holding credentials, that LaunchDarkly does not return as is
+	sensitive map[string]bool
+}
+
+var destinationKinds = map[string]destinationKind{
+	"kinesis": {
+		kind: "kinesis",
+		fields: map[string]string{
+			"region":      "region",
+			"role_arn":    "roleArn",
+			"stream_name": "streamName",
+		},
+	},
+	"google_pubsub": {
+		kind: "google-pubsub",
+		fields: map[string]string{
+			"project": "project",
+			"topic":   "topic",
+		},
+	},
+	"mparticle": {
+		kind: "mparticle",
+		fields: map[string]string{
+			"api_key":       "apiKey",
+			"secret":        "secret",
+			"user_identity": "userIdentity",
+			"environment":   "environment",
+		},
+		sensitive: map[string]bool{"api_key": true, "secret": true},
+	},
+	"segment": {
+		kind: "segment",
+		fields: map[string]string{
+			"write_key": "writeKey",
+		},
+		sensitive: map[string]bool{"write_key": true},
+	},
+}
+
+var destinationBlocks = []string{"kinesis", "google_pubsub", "mparticle", "segment"}
+
+func destinationConfigSchema(block string, fields map[string]*schema.Schema) *schema.Schema {
+	conflicts := make([]string, 0, len(destinationBlocks)-1)
+	for _, other := range destinationBlocks {
+		if other != block {
+			conflicts = append(conflicts, other)
+		}
+	}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflicts,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func requiredDestinationField(sensitive bool) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Sensitive:    sensitive,
+		ValidateFunc: validation.NoZeroValues,
+	}
+}
+
+func resourceDestination() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDestinationCreate,
+		Read:   resourceDestinationRead,
+		Update: resourceDestinationUpdate,
+		Delete: resourceDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDestinationImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceDestinationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"env_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"on": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"kinesis": destinationConfigSchema("kinesis", map[string]*schema.Schema{
+				"region":      requiredDestinationField(false),
+				"role_arn":    requiredDestinationField(false),
+				"stream_name": requiredDestinationField(false),
+			}),
+			"google_pubsub": destinationConfigSchema("google_pubsub", map[string]*schema.Schema{
+				"project": requiredDestinationField(false),
+				"topic":   requiredDestinationField(false),
+			}),
+			"mparticle": destinationConfigSchema("mparticle", map[string]*schema.Schema{
+				"api_key":       requiredDestinationField(true),
+				"secret":        requiredDestinationField(true),
+				"user_identity": requiredDestinationField(false),
+				"environment": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"production", "development"}, false),
+				},
+			}),
+			"segment": destinationConfigSchema("segment", map[string]*schema.Schema{
+				"write_key": requiredDestinationField(true),
+			}),
+		},
+	}
+}
+
+// getDestinationBlock returns the configuration block that is set, and its attributes.
+func getDestinationBlock(get func(string) interface{}) (string, map[string]interface{}) {
+	for _, block := range destinationBlocks {
+		configs, _ := get(block).([]interface{})
+		if len(configs) > 0 {
+			config, _ := configs[0].(map[string]interface{})
+			return block, config
+		}
+	}
+	return "", nil
+}
+
+// The kind of a destination cannot be changed, the destination is recreated when the configuration
+// block changes.
+func resourceDestinationCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	newBlock, _ := getDestinationBlock(d.Get)
+	if len(newBlock) == 0 {
+		return fmt.Errorf("one of %v must be set", destinationBlocks)
+	}
+
+	if len(d.Id()) > 0 {
+		oldBlock, _ := getDestinationBlock(func(key string) interface{} {
+			old, _ := d.GetChange(key)
+			return old
+		})
+		if len(oldBlock) > 0 && oldBlock != newBlock {
+			return d.ForceNew(newBlock)
+		}
+	}
+	return nil
+}
+
+func getDestinationId(project string, environment string, destination string) string {
+	return fmt.Sprintf("%s:%s:%s", project, environment, destination)
+}
+
+func resourceDestinationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	project, environment, _, err := parseThreePartID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("project_key", project)
+	d.Set("env_key", environment)
+
+	if err := resourceDestinationRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceDestinationCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	environment := d.Get("env_key").(string)
+
+	block, config := getDestinationBlock(d.Get)
+	if len(block) == 0 {
+		return fmt.Errorf("one of %v must be set", destinationBlocks)
+	}
+
+	payload := JsonDestination{
+		Name:   d.Get("name").(string),
+		Kind:   destinationKinds[block].kind,
+		Config: transformDestinationConfigFromTerraformFormat(block, config),
+		On:     d.Get("on").(bool),
+	}
+
+	var response JsonDestination
+	err := client.Post(ctx, client.getDestinationCreateUrl(project, environment), payload, []int{201}, &response)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(getDestinationId(project, environment, response.Id))
+
+	return resourceDestinationRead(d, m)
+}
+
+func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	project, environment, destination, err := parseThreePartID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var response JsonDestination
+	err = client.GetInto(ctx, client.getDestinationUrl(project, environment, destination), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", response.Name)
+	d.Set("on", response.On)
+	for block, kind := range destinationKinds {
+		if kind.kind != response.Kind {
+			continue
+		}
+		_, stateConfig := getDestinationBlock(d.Get)
+		if err := d.Set(block, []map[string]interface{}{transformDestinationConfigFromLaunchDarklyFormat(block, response.Config, stateConfig)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceDestinationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	project, environment, destination, err := parseThreePartID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	block, config := getDestinationBlock(d.Get)
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/on",
+		"value": d.Get("on").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/config",
+		"value": transformDestinationConfigFromTerraformFormat(block, config),
+	}}
+
+	_, err = client.Patch(ctx, client.getDestinationUrl(project, environment, destination), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceDestinationRead(d, m)
+}
+
+func resourceDestinationDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	project, environment, destination, err := parseThreePartID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = client.Delete(ctx, client.getDestinationUrl(project, environment, destination), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func transformDestinationConfigFromTerraformFormat(block string, config map[string]interface{}) map[string]interface{} {
+	transformed := make(map[string]interface{})
+	for attribute, field := range destinationKinds[block].fields {
+		transformed[field] = config[attribute]
+	}
+	return transformed
+}
+
+// transformDestinationConfigFromLaunchDarklyFormat reads back the config of a destination. Credentials
+// are kept from the state, since LaunchDarkly obfuscates them.
+func transformDestinationConfigFromLaunchDarklyFormat(block string, config map[string]interface{}, stateConfig map[string]interface{}) map[string]interface{} {
+	kind := destinationKinds[block]
+	transformed := make(map[string]interface{})
+	for attribute, field := range kind.fields {
+		if kind.sensitive[attribute] {
+			transformed[attribute] = stateConfig[attribute]
+			continue
+		}
+		if value, ok := config[field]; ok {
+			transformed[attribute] = fmt.Sprint(value)
+		}
+	}
+	return transformed
+}
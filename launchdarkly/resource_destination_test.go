@@ -0,0 +1,126 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// newTestDestinationServer obfuscates the credentials it returns, like LaunchDarkly.
+func newTestDestinationServer(t *testing.T) (*httptest.Server, *map[string]interface{}) {
+	return newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/destinations/my-project/production",
+		path:       "/api/v2/destinations/my-project/production/destination-id",
+		id:         "destination-id",
+		respond: func(r *http.Request, response map[string]interface{}) map[string]interface{} {
+			config := make(map[string]interface{})
+			for key, value := range response["config"].(map[string]interface{}) {
+				config[key] = value
+				if key == "apiKey" || key == "secret" {
+					config[key] = "********"
+				}
+			}
+			response["config"] = config
+			return response
+		},
+	})
+}
+
+func TestResourceDestinationCreate(t *testing.T) {
+	server, document := newTestDestinationServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceDestination().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"env_key":     "production",
+		"name":        "mParticle",
+		"on":          true,
+		"mparticle": []interface{}{map[string]interface{}{
+			"api_key":       "mparticle-key",
+			"secret":        "mparticle-secret",
+			"user_identity": "customer_id",
+			"environment":   "production",
+		}},
+	})
+
+	if err := resourceDestinationCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "my-project:production:destination-id" {
+		t.Errorf("got id (%s) but want (my-project:production:destination-id)", d.Id())
+	}
+	testPayloadVerify(t, *document, `{
+		"_id": "destination-id",
+		"name": "mParticle",
+		"kind": "mparticle",
+		"on": true,
+		"config": {"apiKey": "mparticle-key", "secret": "mparticle-secret", "userIdentity": "customer_id", "environment": "production"}
+	}`)
+	if d.Get("mparticle.0.secret") != "mparticle-secret" || d.Get("mparticle.0.user_identity") != "customer_id" {
+		t.Errorf("the destination was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceDestinationChangeKind(t *testing.T) {
+	resource := resourceDestination()
+	state := &terraform.InstanceState{
+		ID: "my-project:production:destination-id",
+		Attributes: map[string]string{
+			"id":                  "my-project:production:destination-id",
+			"project_key":         "my-project",
+			"env_key":             "production",
+			"name":                "Events",
+			"on":                  "true",
+			"segment.#":           "1",
+			"segment.0.write_key": "segment-key",
+			"kinesis.#":           "0",
+			"google_pubsub.#":     "0",
+			"mparticle.#":         "0",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		config        map[string]interface{}
+		wantedReplace bool
+	}{
+		{
+			name:   "same kind",
+			config: map[string]interface{}{"segment": []interface{}{map[string]interface{}{"write_key": "other-key"}}},
+		},
+		{
+			name: "other kind",
+			config: map[string]interface{}{"google_pubsub": []interface{}{map[string]interface{}{
+				"project": "my-gcp-project",
+				"topic":   "flag-events",
+			}}},
+			wantedReplace: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"project_key": "my-project",
+				"env_key":     "production",
+				"name":        "Events",
+				"on":          true,
+			}
+			for key, value := range testCase.config {
+				config[key] = value
+			}
+
+			diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if diff.RequiresNew() != testCase.wantedReplace {
+				t.Errorf("got replacement (%v) but want (%v): %v", diff.RequiresNew(), testCase.wantedReplace, diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 // Since we cannot delete the last environment in a project, we use a hack with a temporary dummy
@@ -21,6 +22,8 @@ func resourceEnvironment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceEnvironmentImport,
 		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceEnvironmentCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"project_key": {
@@ -50,16 +53,58 @@ func resourceEnvironment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"secure_mode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Minutes that the PHP SDK may cache the flag rules locally
+			"default_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 60),
+			},
+			"default_track_events": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"require_comments": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"confirm_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"approval_settings": approvalSettingsSchema(),
 		},
 	}
 }
 
+func resourceEnvironmentCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("approval_settings") {
+		return nil
+	}
+	return validateApprovalSettings(d.Get("approval_settings"))
+}
+
 func resourceEnvironmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return resourceImport(resourceEnvironmentRead, d, meta)
 }
 
 func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	environmentMutex.Lock()
 	defer environmentMutex.Unlock()
@@ -70,23 +115,37 @@ func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
 	color := d.Get("color").(string)
 
 	payload := JsonEnvironment{
-		Name:  name,
-		Key:   key,
-		Color: color,
+		Name:               name,
+		Key:                key,
+		Color:              color,
+		SecureMode:         d.Get("secure_mode").(bool),
+		DefaultTtl:         d.Get("default_ttl").(int),
+		DefaultTrackEvents: d.Get("default_track_events").(bool),
+		RequireComments:    d.Get("require_comments").(bool),
+		ConfirmChanges:     d.Get("confirm_changes").(bool),
+		Tags:               transformStringSetFromTerraformFormat(d.Get("tags")),
 	}
 
 	var response JsonEnvironment
-	err := client.Post(getEnvironmentCreateUrl(project), payload, []int{201}, &response)
+	err := client.Post(ctx, client.getEnvironmentCreateUrl(project), payload, []int{201}, &response)
 	if err != nil {
 		return err
 	}
 
 	// If a dummy environment was created before, we no longer need it
-	err = ensureThereIsNoDummyEnvironment(client, project)
+	err = ensureThereIsNoDummyEnvironment(ctx, client, project)
 	if err != nil {
 		return err
 	}
 
+	// The approval settings cannot be given when creating the environment
+	if getApprovalSettings(d.Get("approval_settings")) != nil {
+		_, err = client.Patch(ctx, client.getEnvironmentUrl(project, key), createPayloadForApprovalSettings(d), []int{200})
+		if err != nil {
+			return err
+		}
+	}
+
 	d.SetId(key)
 	d.Set("name", name)
 	d.Set("key", key)
@@ -98,17 +157,28 @@ func resourceEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	return readEnvironment(d, m, getApprovalSettings(d.Get("approval_settings")) != nil)
+}
+
+// readEnvironment reads the environment, the approval settings being read back even when they are the
+// defaults if managed is set.
+func readEnvironment(d *schema.ResourceData, m interface{}, managed bool) error {
 	project := d.Get("project_key").(string)
 	key := d.Get("key").(string)
 
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	var response JsonEnvironment
-	err := client.GetInto(getEnvironmentUrl(project, key), []int{200}, &response)
-	if err != nil {
+	err := client.GetInto(ctx, client.getEnvironmentUrl(project, key), []int{200}, &response)
+	if isNotFound(err) {
 		d.SetId("")
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 
 	d.SetId(key)
 	d.Set("name", response.Name)
@@ -116,12 +186,25 @@ func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("color", response.Color)
 	d.Set("api_key", response.ApiKey)
 	d.Set("mobile_key", response.MobileKey)
+	d.Set("secure_mode", response.SecureMode)
+	d.Set("default_ttl", response.DefaultTtl)
+	d.Set("default_track_events", response.DefaultTrackEvents)
+	d.Set("require_comments", response.RequireComments)
+	d.Set("confirm_changes", response.ConfirmChanges)
+	if err := d.Set("tags", response.Tags); err != nil {
+		return err
+	}
+	if err := d.Set("approval_settings", transformApprovalSettingsFromLaunchDarklyFormat(response.ApprovalSettings, managed)); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	environmentMutex.Lock()
 	defer environmentMutex.Unlock()
@@ -130,7 +213,7 @@ func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
 	name := d.Get("name").(string)
 	color := d.Get("color").(string)
 
-	payload := []map[string]string{{
+	payload := []map[string]interface{}{{
 		"op":    "replace",
 		"path":  "/name",
 		"value": name,
@@ -138,9 +221,36 @@ func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
 		"op":    "replace",
 		"path":  "/color",
 		"value": color,
+	}, {
+		"op":    "replace",
+		"path":  "/secureMode",
+		"value": d.Get("secure_mode").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/defaultTtl",
+		"value": d.Get("default_ttl").(int),
+	}, {
+		"op":    "replace",
+		"path":  "/defaultTrackEvents",
+		"value": d.Get("default_track_events").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/requireComments",
+		"value": d.Get("require_comments").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/confirmChanges",
+		"value": d.Get("confirm_changes").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/tags",
+		"value": transformStringSetFromTerraformFormat(d.Get("tags")),
 	}}
+	if d.HasChange("approval_settings") {
+		payload = append(payload, createPayloadForApprovalSettings(d)...)
+	}
 
-	_, err := client.Patch(getEnvironmentUrl(project, d.Id()), payload, []int{200}, 0)
+	_, err := client.Patch(ctx, client.getEnvironmentUrl(project, d.Id()), payload, []int{200})
 	if err != nil {
 		return err
 	}
@@ -148,20 +258,30 @@ func resourceEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func createPayloadForApprovalSettings(d *schema.ResourceData) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/approvalSettings",
+		"value": transformApprovalSettingsFromTerraformFormat(d.Get("approval_settings")),
+	}}
+}
+
 func resourceEnvironmentDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	environmentMutex.Lock()
 	defer environmentMutex.Unlock()
 
 	project := d.Get("project_key").(string)
 
-	err := ensureWeCanDeleteEnvironment(client, project)
+	err := ensureWeCanDeleteEnvironment(ctx, client, project)
 	if err != nil {
 		return err
 	}
 
-	err = client.Delete(getEnvironmentUrl(project, d.Id()), []int{204, 404})
+	err = client.Delete(ctx, client.getEnvironmentUrl(project, d.Id()), []int{204, 404})
 	if err != nil {
 		return err
 	}
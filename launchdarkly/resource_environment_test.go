@@ -0,0 +1,162 @@
+package launchdarkly
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// newTestEnvironmentServer serves the production environment of a project.
+func newTestEnvironmentServer(t *testing.T, document map[string]interface{}) *httptest.Server {
+	server, _ := newTestResourceServer(t, testResourceServer{
+		path:     "/api/v2/projects/my-project/environments/production",
+		document: document,
+	})
+	return server
+}
+
+func TestResourceEnvironmentUpdateSettings(t *testing.T) {
+	document := map[string]interface{}{
+		"name":  "Production",
+		"key":   "production",
+		"color": "FF0000",
+	}
+	server := newTestEnvironmentServer(t, document)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceEnvironment().Schema, map[string]interface{}{
+		"project_key":      "my-project",
+		"key":              "production",
+		"name":             "Production",
+		"color":            "FF0000",
+		"secure_mode":      true,
+		"default_ttl":      5,
+		"require_comments": true,
+		"confirm_changes":  true,
+		"tags":             []interface{}{"critical"},
+	})
+	d.SetId("production")
+
+	client := newTestClient(server)
+	if err := resourceEnvironmentUpdate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testPayloadVerify(t, document, `{
+		"name": "Production",
+		"key": "production",
+		"color": "FF0000",
+		"secureMode": true,
+		"defaultTtl": 5,
+		"defaultTrackEvents": false,
+		"requireComments": true,
+		"confirmChanges": true,
+		"tags": ["critical"]
+	}`)
+
+	// Settings changed outside of Terraform are read back as drift
+	document["requireComments"] = false
+	if err := resourceEnvironmentRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("require_comments").(bool) || !d.Get("confirm_changes").(bool) || d.Get("default_ttl").(int) != 5 {
+		t.Errorf("the environment settings were not read back as expected: %v", d.State())
+	}
+	if tags := d.Get("tags").(*schema.Set); tags.Len() != 1 || !tags.Contains("critical") {
+		t.Errorf("got tags (%v) but want ([critical])", tags.List())
+	}
+}
+
+func TestResourceEnvironmentUpdateApprovalSettings(t *testing.T) {
+	document := map[string]interface{}{
+		"name":  "Production",
+		"key":   "production",
+		"color": "FF0000",
+		"approvalSettings": map[string]interface{}{
+			"required":                false,
+			"canReviewOwnRequest":     false,
+			"minNumApprovals":         1,
+			"canApplyDeclinedChanges": true,
+			"requiredApprovalTags":    []interface{}{},
+		},
+	}
+	server := newTestEnvironmentServer(t, document)
+	defer server.Close()
+
+	resource := resourceEnvironment()
+	state := &terraform.InstanceState{
+		ID: "production",
+		Attributes: map[string]string{
+			"id":          "production",
+			"project_key": "my-project",
+			"key":         "production",
+			"name":        "Production",
+			"color":       "FF0000",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "production",
+		"name":        "Production",
+		"color":       "FF0000",
+		"approval_settings": []interface{}{map[string]interface{}{
+			"required":          true,
+			"min_num_approvals": 2,
+		}},
+	})
+	diff, err := resource.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := resourceEnvironmentUpdate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testPayloadVerify(t, document["approvalSettings"], `{
+		"required": true,
+		"canReviewOwnRequest": false,
+		"minNumApprovals": 2,
+		"canApplyDeclinedChanges": true,
+		"requiredApprovalTags": []
+	}`)
+
+	if err := resourceEnvironmentRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !d.Get("approval_settings.0.required").(bool) || d.Get("approval_settings.0.min_num_approvals").(int) != 2 {
+		t.Errorf("the approval settings were not read back as expected: %v", d.State())
+	}
+}
+
+func TestDataSourceEnvironmentReadApprovalSettings(t *testing.T) {
+	// Approvals are not required, the settings must be read anyway
+	server := newTestEnvironmentServer(t, map[string]interface{}{
+		"name": "Production",
+		"key":  "production",
+		"approvalSettings": map[string]interface{}{
+			"required":                false,
+			"canReviewOwnRequest":     true,
+			"minNumApprovals":         2,
+			"canApplyDeclinedChanges": false,
+			"requiredApprovalTags":    []interface{}{},
+		},
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceEnvironment().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "production",
+	})
+
+	if err := dataSourceEnvironmentRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("approval_settings.#") != 1 || d.Get("approval_settings.0.min_num_approvals") != 2 || d.Get("approval_settings.0.can_review_own_request") != true {
+		t.Errorf("the approval settings were not read as expected: %v", d.State())
+	}
+}
@@ -1,11 +1,12 @@
 package launchdarkly
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strconv"
-	"sort"
 	"reflect"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -17,7 +18,6 @@ const VARIATIONS_STRING_KIND = "string"
 const VARIATIONS_NUMBER_KIND = "number"
 const VARIATIONS_BOOLEAN_KIND = "boolean"
 const DEFAULT_VARIATIONS_KIND = VARIATIONS_BOOLEAN_KIND
-const NUMBER_OF_RETRY = 3
 
 func resourceFeatureFlag() *schema.Resource {
 	return &schema.Resource{
@@ -28,6 +28,8 @@ func resourceFeatureFlag() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceFeatureFlagImport,
 		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceFeatureFlagCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"project_key": {
@@ -72,13 +74,14 @@ func resourceFeatureFlag() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"value": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
 							ValidateFunc: validateVariationValue,
 						},
+						"rollout": rolloutSchema(),
 						"environment": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validateKey ,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateKey,
 						},
 					},
 				},
@@ -94,10 +97,31 @@ func resourceFeatureFlag() *schema.Resource {
 							ValidateFunc: validateVariationValue,
 						},
 						"environment": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validateKey ,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateKey,
+						},
+					},
+				},
+			},
+			"environment": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateKey,
+						},
+						"on": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
 						},
+						"rules":         rulesSchema(),
+						"targets":       targetsSchema(),
+						"prerequisites": prerequisitesSchema(),
 					},
 				},
 			},
@@ -154,12 +178,42 @@ func resourceFeatureFlag() *schema.Resource {
 	}
 }
 
+func resourceFeatureFlagCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	defaultTargetingRules, _ := d.Get("default_targeting_rule").([]interface{})
+	for _, rawTargetingRule := range defaultTargetingRules {
+		if targetingRule, ok := rawTargetingRule.(map[string]interface{}); ok {
+			// Without a value nor a rollout, the first variation is served
+			if err := validateVariationOrRollout(targetingRule, "value", false); err != nil {
+				return fmt.Errorf("default_targeting_rule %v: %s", targetingRule["environment"], err)
+			}
+		}
+	}
+
+	environments, _ := d.Get("environment").([]interface{})
+	if err := validateFlagEnvironments(environments); err != nil {
+		return err
+	}
+
+	project, _ := d.Get("project_key").(string)
+	for _, rawEnvironment := range environments {
+		if environment, ok := rawEnvironment.(map[string]interface{}); ok {
+			prerequisites, _ := environment["prerequisites"].([]interface{})
+			if err := validatePrerequisitesWithClient(m, project, prerequisites); err != nil {
+				return fmt.Errorf("environment %v: %s", environment["key"], err)
+			}
+		}
+	}
+	return nil
+}
+
 func resourceFeatureFlagImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return resourceImport(resourceFeatureFlagRead, d, meta)
 }
 
 func resourceFeatureFlagCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	project := d.Get("project_key").(string)
 	name := d.Get("name").(string)
@@ -172,6 +226,7 @@ func resourceFeatureFlagCreate(d *schema.ResourceData, m interface{}) error {
 	variations := d.Get("variations").([]interface{})
 	defaultTargetingRule := d.Get("default_targeting_rule").([]interface{})
 	defaultOffTargetingRule := d.Get("default_off_targeting_rule").([]interface{})
+	environments := d.Get("environment").([]interface{})
 	customProperties := d.Get("custom_properties").([]interface{})
 	transformedTags := make([]string, len(tags))
 	sort.Strings(transformedTags)
@@ -198,7 +253,7 @@ func resourceFeatureFlagCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	var response JsonFeatureFlag
-	err = client.Post(getFlagCreateUrl(project), payload, []int{201}, &response)
+	err = client.Post(ctx, client.getFlagCreateUrl(project), payload, []int{201}, &response)
 	if err != nil {
 		return err
 	}
@@ -212,10 +267,22 @@ func resourceFeatureFlagCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	prerequisiteFlags, err := getFlagsVariations(ctx, client, project, getFlagEnvironmentsPrerequisiteFlagKeys(environments))
+	if err != nil {
+		return err
+	}
+
+	// The variations of the created flag are used since boolean flags may not declare any
+	environmentsPayload, err := createPayloadForFlagEnvironments(environments, getVariationsFromLaunchDarklyFormat(response.Variations), prerequisiteFlags)
+	if err != nil {
+		return err
+	}
+
 	patchPayload := append(defaultTargetinRulePayload, offOffTargetingRulePayload...)
+	patchPayload = append(patchPayload, environmentsPayload...)
 
 	if len(patchPayload) > 0 {
-		_, err = client.Patch(getFlagUrl(project, key), patchPayload, []int{200}, NUMBER_OF_RETRY)
+		_, err = client.Patch(ctx, client.getFlagUrl(project, key), patchPayload, []int{200})
 		if err != nil {
 			return err
 		}
@@ -232,6 +299,7 @@ func resourceFeatureFlagCreate(d *schema.ResourceData, m interface{}) error {
 	d.Set("custom_properties", customProperties)
 	d.Set("default_targeting_rule", defaultTargetingRule)
 	d.Set("default_off_targeting_rule", defaultOffTargetingRule)
+	d.Set("environment", environments)
 
 	return nil
 }
@@ -241,13 +309,18 @@ func resourceFeatureFlagRead(d *schema.ResourceData, m interface{}) error {
 	key := d.Get("key").(string)
 
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	var response JsonFeatureFlag
-	err := client.GetInto(getFlagUrl(project, key), []int{200}, &response)
-	if err != nil {
+	err := client.GetInto(ctx, client.getFlagUrl(project, key), []int{200}, &response)
+	if isNotFound(err) {
 		d.SetId("")
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 	transformedVariations := transformVariationsFromLaunchDarklyFormat(response.Variations)
 	transformedCustomProperties := transformCustomPropertiesFromLaunchDarklyFormat(response.CustomProperties)
 
@@ -273,12 +346,26 @@ func resourceFeatureFlagRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("custom_properties", transformedCustomProperties); err != nil {
 		return err
 	}
-	
+
+	// Only the environments declared in the configuration are managed by this resource. The data
+	// source shares this function but has no environment attribute, hence the checked assertion.
+	if environments, ok := d.Get("environment").([]interface{}); ok && len(environments) > 0 {
+		prerequisiteFlags, err := getFlagsVariations(ctx, client, project, getManagedFlagEnvironmentsPrerequisiteFlagKeys(environments, response))
+		if err != nil {
+			return err
+		}
+		if err := d.Set("environment", transformFlagEnvironmentsFromLaunchDarklyFormat(environments, response, prerequisiteFlags)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func resourceFeatureFlagUpdate(resourceData *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(resourceData.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 	project := resourceData.Get("project_key").(string)
 	name := resourceData.Get("name").(string)
 	description := resourceData.Get("description").(string)
@@ -289,16 +376,17 @@ func resourceFeatureFlagUpdate(resourceData *schema.ResourceData, m interface{})
 	variations := resourceData.Get("variations").([]interface{})
 	defaultTargetingRule := resourceData.Get("default_targeting_rule").([]interface{})
 	defaultOffTargetingRule := resourceData.Get("default_off_targeting_rule").([]interface{})
+	environments := resourceData.Get("environment").([]interface{})
 
 	transformedCustomProperties, err := transformCustomPropertiesFromTerraformFormat(customProperties)
 	if err != nil {
 		return err
 	}
 
-	if err := applyChangesToVariations(resourceData, client); err != nil {
+	if err := applyChangesToVariations(ctx, resourceData, client); err != nil {
 		return err
 	}
-	
+
 	defaultTargetingRulePayload, err := createPayloadForDefaultTargeting(defaultTargetingRule, variations)
 	if err != nil {
 		return err
@@ -308,7 +396,18 @@ func resourceFeatureFlagUpdate(resourceData *schema.ResourceData, m interface{})
 		return err
 	}
 
+	prerequisiteFlags, err := getFlagsVariations(ctx, client, project, getFlagEnvironmentsPrerequisiteFlagKeys(environments))
+	if err != nil {
+		return err
+	}
+
+	environmentsPayload, err := createPayloadForFlagEnvironments(environments, variations, prerequisiteFlags)
+	if err != nil {
+		return err
+	}
+
 	targetingPayload := append(defaultTargetingRulePayload, defaultOffTargetingRulePayload...)
+	targetingPayload = append(targetingPayload, environmentsPayload...)
 
 	mainPayload := []map[string]interface{}{{
 		"op":    "replace",
@@ -338,7 +437,7 @@ func resourceFeatureFlagUpdate(resourceData *schema.ResourceData, m interface{})
 
 	payload := append(mainPayload, targetingPayload...)
 
-	_, err = client.Patch(getFlagUrl(project, resourceData.Id()), payload, []int{200}, NUMBER_OF_RETRY)
+	_, err = client.Patch(ctx, client.getFlagUrl(project, resourceData.Id()), payload, []int{200})
 	if err != nil {
 		return err
 	}
@@ -348,10 +447,12 @@ func resourceFeatureFlagUpdate(resourceData *schema.ResourceData, m interface{})
 
 func resourceFeatureFlagDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	project := d.Get("project_key").(string)
 
-	err := client.Delete(getFlagUrl(project, d.Id()), []int{204, 404})
+	err := client.Delete(ctx, client.getFlagUrl(project, d.Id()), []int{204, 404})
 	if err != nil {
 		return err
 	}
@@ -375,19 +476,31 @@ func createPayloadForDefaultTargeting(defaultTargetingRules []interface{}, varia
 	for index, defaultTargetingRule := range defaultTargetingRules {
 		targetingRule := defaultTargetingRule.(map[string]interface{})
 
-		variationIndex, err := getDefaultVariationIndex(variations, targetingRule["value"].(string))
-		if err != nil {
-			return nil, err
+		// The whole fallthrough is replaced so that switching between a variation and a rollout
+		// does not leave the other one behind
+		var fallthroughValue JsonFallthrough
+		if rollout := getRollout(targetingRule); rollout != nil {
+			transformedRollout, err := transformRolloutFromTerraformFormat(rollout, variations)
+			if err != nil {
+				return nil, err
+			}
+			fallthroughValue.Rollout = transformedRollout
+		} else {
+			variationIndex, err := getDefaultVariationIndex(variations, targetingRule["value"].(string))
+			if err != nil {
+				return nil, err
+			}
+			fallthroughValue.Variation = &variationIndex
 		}
 
 		patchPayload[index] = map[string]interface{}{
 			"op":    "replace",
-			"path":  fmt.Sprintf("/environments/%s/fallthrough/variation", targetingRule["environment"].(string)),
-			"value": variationIndex,
+			"path":  fmt.Sprintf("/environments/%s/fallthrough", targetingRule["environment"].(string)),
+			"value": fallthroughValue,
 		}
 	}
 	return patchPayload, nil
-} 
+}
 
 func createPayloadForDefaultOffTargeting(defaultOffTargetingRules []interface{}, variations []interface{}) ([]map[string]interface{}, error) {
 	patchPayload := make([]map[string]interface{}, len(defaultOffTargetingRules))
@@ -405,7 +518,7 @@ func createPayloadForDefaultOffTargeting(defaultOffTargetingRules []interface{},
 		}
 	}
 	return patchPayload, nil
-} 
+}
 
 func getDefaultOffVariationIndex(variations []interface{}, variationValue string) (int, error) {
 	if len(variations) > 0 {
@@ -492,13 +605,13 @@ func transformCustomPropertiesFromTerraformFormat(properties []interface{}) (map
 	return transformed, nil
 }
 
-func applyChangesToVariations(resourceData *schema.ResourceData, client Client) error {
+func applyChangesToVariations(ctx context.Context, resourceData *schema.ResourceData, client Client) error {
 	project := resourceData.Get("project_key").(string)
 	key := resourceData.Id()
 	variations := resourceData.Get("variations").([]interface{})
 
 	var response JsonFeatureFlag
-	err := client.GetInto(getFlagUrl(project, key), []int{200}, &response)
+	err := client.GetInto(ctx, client.getFlagUrl(project, key), []int{200}, &response)
 	if err != nil {
 		return err
 	}
@@ -513,16 +626,16 @@ func applyChangesToVariations(resourceData *schema.ResourceData, client Client)
 
 	//Remove variations
 	if newNumberOfVariation < actualNumberOfVariation {
-		var deletePayloadValue []interface{} = make([]interface{}, actualNumberOfVariation - newNumberOfVariation)
+		var deletePayloadValue []interface{} = make([]interface{}, actualNumberOfVariation-newNumberOfVariation)
 		for i := 0; i < len(deletePayloadValue); i++ {
-			removeValue := map[string]interface{}{ 
+			removeValue := map[string]interface{}{
 				"op":   "remove",
-				"path": fmt.Sprintf("/variations/%d", actualNumberOfVariation - 1),
+				"path": fmt.Sprintf("/variations/%d", actualNumberOfVariation-1),
 			}
 			deletePayloadValue[i] = removeValue
 			actualNumberOfVariation--
 		}
-		_, err = client.Patch(getFlagUrl(project, key), deletePayloadValue, []int{200}, NUMBER_OF_RETRY)
+		_, err = client.Patch(ctx, client.getFlagUrl(project, key), deletePayloadValue, []int{200})
 		if err != nil {
 			return err
 		}
@@ -548,7 +661,7 @@ func applyChangesToVariations(resourceData *schema.ResourceData, client Client)
 			updatePayloadValue[(i*3)+1] = replaceName
 			updatePayloadValue[(i*3)+2] = replaceDescription
 		}
-		_, err = client.Patch(getFlagUrl(project, key), updatePayloadValue, []int{200}, NUMBER_OF_RETRY)
+		_, err = client.Patch(ctx, client.getFlagUrl(project, key), updatePayloadValue, []int{200})
 		if err != nil {
 			return err
 		}
@@ -578,20 +691,20 @@ func applyChangesToVariations(resourceData *schema.ResourceData, client Client)
 			updatePayloadValue[(i*3)+1] = replaceName
 			updatePayloadValue[(i*3)+2] = replaceDescription
 		}
-		_, err = client.Patch(getFlagUrl(project, key), updatePayloadValue, []int{200}, NUMBER_OF_RETRY)
+		_, err = client.Patch(ctx, client.getFlagUrl(project, key), updatePayloadValue, []int{200})
 		if err != nil {
 			return err
 		}
-		
-		var createPayloadValue []interface{} = make([]interface{}, newNumberOfVariation - actualNumberOfVariation)
+
+		var createPayloadValue []interface{} = make([]interface{}, newNumberOfVariation-actualNumberOfVariation)
 		for i := 0; i < len(createPayloadValue); i++ {
 			createPayloadValue[i] = map[string]interface{}{
 				"op":    "add",
-				"path":  fmt.Sprintf("/variations/%d", actualNumberOfVariation + i),
-				"value": transformedVariations[actualNumberOfVariation + i],
+				"path":  fmt.Sprintf("/variations/%d", actualNumberOfVariation+i),
+				"value": transformedVariations[actualNumberOfVariation+i],
 			}
 		}
-		_, err = client.Patch(getFlagUrl(project, key), createPayloadValue, []int{200}, NUMBER_OF_RETRY)
+		_, err = client.Patch(ctx, client.getFlagUrl(project, key), createPayloadValue, []int{200})
 		if err != nil {
 			return err
 		}
@@ -605,7 +718,7 @@ func transformVariationsFromLaunchDarklyFormat(properties []JsonVariations) inte
 	for _, variation := range properties {
 		transformedVariation := make(map[string]interface{})
 		transformedVariation["name"] = variation.Name
-		transformedVariation["value"] = fmt.Sprint(variation.Value)
+		transformedVariation["value"] = formatValue(variation.Value)
 		transformedVariation["description"] = variation.Description
 
 		transformedVariations = append(transformedVariations, transformedVariation)
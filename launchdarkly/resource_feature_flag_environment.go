@@ -0,0 +1,260 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceFeatureFlagEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceFeatureFlagEnvironmentCreate,
+		Read:   resourceFeatureFlagEnvironmentRead,
+		Update: resourceFeatureFlagEnvironmentUpdate,
+		Delete: resourceFeatureFlagEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceFeatureFlagEnvironmentImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceFeatureFlagEnvironmentCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"flag_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateFeatureFlagKey,
+			},
+			"env_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"on": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"fallthrough": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variation": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateVariationValue,
+						},
+						"rollout": rolloutSchema(),
+					},
+				},
+			},
+			"off_variation": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariationValue,
+			},
+			"track_events": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rules":         rulesSchema(),
+			"targets":       targetsSchema(),
+			"prerequisites": prerequisitesSchema(),
+		},
+	}
+}
+
+func resourceFeatureFlagEnvironmentCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	fallthroughs, _ := d.Get("fallthrough").([]interface{})
+	for _, rawFallthrough := range fallthroughs {
+		if fallthroughValue, ok := rawFallthrough.(map[string]interface{}); ok {
+			if err := validateVariationOrRollout(fallthroughValue, "variation", true); err != nil {
+				return fmt.Errorf("fallthrough: %s", err)
+			}
+		}
+	}
+
+	rules, _ := d.Get("rules").([]interface{})
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+
+	prerequisites, _ := d.Get("prerequisites").([]interface{})
+	project, _ := d.Get("project_key").(string)
+	return validatePrerequisitesWithClient(m, project, prerequisites)
+}
+
+func getFeatureFlagEnvironmentId(project string, flag string, environment string) string {
+	return fmt.Sprintf("%s:%s:%s", project, flag, environment)
+}
+
+func resourceFeatureFlagEnvironmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	project, flag, environment, err := parseThreePartID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("project_key", project)
+	d.Set("flag_key", flag)
+	d.Set("env_key", environment)
+
+	if err := resourceFeatureFlagEnvironmentRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceFeatureFlagEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
+	if err := applyFeatureFlagEnvironment(d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(getFeatureFlagEnvironmentId(d.Get("project_key").(string), d.Get("flag_key").(string), d.Get("env_key").(string)))
+
+	return resourceFeatureFlagEnvironmentRead(d, m)
+}
+
+func resourceFeatureFlagEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project_key").(string)
+	flag := d.Get("flag_key").(string)
+	environment := d.Get("env_key").(string)
+
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonFeatureFlag
+	err := client.GetInto(ctx, client.getFlagUrl(project, flag), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The environment itself may have been deleted
+	flagEnvironment, found := response.Environments[environment]
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(getFeatureFlagEnvironmentId(project, flag, environment))
+	d.Set("on", flagEnvironment.On)
+	d.Set("track_events", flagEnvironment.TrackEvents)
+	d.Set("off_variation", getVariationValue(response.Variations, flagEnvironment.OffVariation))
+	if err := d.Set("fallthrough", transformFallthroughFromLaunchDarklyFormat(flagEnvironment.Fallthrough, response.Variations)); err != nil {
+		return err
+	}
+	if err := d.Set("rules", transformRulesFromLaunchDarklyFormat(flagEnvironment.Rules, response.Variations)); err != nil {
+		return err
+	}
+	if err := d.Set("targets", transformTargetsFromLaunchDarklyFormat(flagEnvironment.Targets, response.Variations)); err != nil {
+		return err
+	}
+
+	prerequisiteFlags, err := getFlagsVariations(ctx, client, project, getPrerequisiteFlagKeysFromLaunchDarklyFormat(flagEnvironment.Prerequisites))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("prerequisites", transformPrerequisitesFromLaunchDarklyFormat(flagEnvironment.Prerequisites, prerequisiteFlags)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceFeatureFlagEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyFeatureFlagEnvironment(d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
+
+	return resourceFeatureFlagEnvironmentRead(d, m)
+}
+
+func resourceFeatureFlagEnvironmentDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	flag := d.Get("flag_key").(string)
+	environment := d.Get("env_key").(string)
+
+	var response JsonFeatureFlag
+	err := client.GetInto(ctx, client.getFlagUrl(project, flag), []int{200}, &response)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	payload, err := createPayloadForFlagEnvironmentReset(environment, getVariationsFromLaunchDarklyFormat(response.Variations))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Patch(ctx, client.getFlagUrl(project, flag), payload, []int{200, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func applyFeatureFlagEnvironment(d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(timeout)
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	flag := d.Get("flag_key").(string)
+	environment := d.Get("env_key").(string)
+
+	// Variation values are resolved against the current variations of the flag
+	var response JsonFeatureFlag
+	err := client.GetInto(ctx, client.getFlagUrl(project, flag), []int{200}, &response)
+	if err != nil {
+		return err
+	}
+
+	settings := map[string]interface{}{
+		"on":            d.Get("on"),
+		"track_events":  d.Get("track_events"),
+		"fallthrough":   d.Get("fallthrough"),
+		"off_variation": d.Get("off_variation"),
+		"rules":         d.Get("rules"),
+		"targets":       d.Get("targets"),
+		"prerequisites": d.Get("prerequisites"),
+	}
+
+	prerequisiteFlags, err := getFlagsVariations(ctx, client, project, getPrerequisiteFlagKeysFromTerraformFormat(d.Get("prerequisites").([]interface{})))
+	if err != nil {
+		return err
+	}
+
+	payload, err := createPayloadForFlagEnvironment(environment, settings, getVariationsFromLaunchDarklyFormat(response.Variations), prerequisiteFlags)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Patch(ctx, client.getFlagUrl(project, flag), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// newTestFlagServer serves a single flag as a generic JSON document, applying the JSON patches it
+// receives so that resources can be read back after being written. Other flags, e.g. prerequisites,
+// are served as is under their key and any other flag is not found.
+func newTestFlagServer(t *testing.T, flag string, otherFlags ...string) (*httptest.Server, *map[string]interface{}) {
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(flag), &document); err != nil {
+		t.Fatalf("invalid test flag: %s", err)
+	}
+	others := make(map[string]string)
+	for _, otherFlag := range otherFlags {
+		var otherDocument map[string]interface{}
+		if err := json.Unmarshal([]byte(otherFlag), &otherDocument); err != nil {
+			t.Fatalf("invalid test flag: %s", err)
+		}
+		others[otherDocument["key"].(string)] = otherFlag
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if key != document["key"] {
+			if otherFlag, found := others[key]; found {
+				w.WriteHeader(200)
+				w.Write([]byte(otherFlag))
+				return
+			}
+			w.WriteHeader(404)
+			return
+		}
+
+		if r.Method == "PATCH" {
+			body, _ := ioutil.ReadAll(r.Body)
+			var operations []map[string]interface{}
+			if err := json.Unmarshal(body, &operations); err != nil {
+				t.Errorf("invalid patch: %s", err)
+			}
+			for _, operation := range operations {
+				applyTestPatchOperation(t, document, operation)
+			}
+		}
+
+		response, _ := json.Marshal(document)
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+
+	return server, &document
+}
+
+const testFlag = `{
+	"key": "my-flag",
+	"kind": "string",
+	"variations": [{"value": "blue"}, {"value": "green"}, {"value": "red"}],
+	"environments": {
+		"production": {"on": false, "offVariation": 2, "fallthrough": {"variation": 0}, "trackEvents": false}
+	}
+}`
+
+func TestResourceFeatureFlagEnvironmentCreate(t *testing.T) {
+	server, document := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key":   "my-project",
+		"flag_key":      "my-flag",
+		"env_key":       "production",
+		"on":            true,
+		"track_events":  true,
+		"off_variation": "green",
+		"fallthrough": []interface{}{map[string]interface{}{
+			"variation": "red",
+		}},
+	})
+
+	if err := resourceFeatureFlagEnvironmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "my-project:my-flag:production" {
+		t.Errorf("got id (%s) but want (my-project:my-flag:production)", d.Id())
+	}
+
+	production := (*document)["environments"].(map[string]interface{})["production"].(map[string]interface{})
+	if production["on"] != true || production["trackEvents"] != true || production["offVariation"] != float64(1) {
+		t.Errorf("the environment was not patched as expected: %v", production)
+	}
+	if d.Get("fallthrough.0.variation") != "red" || d.Get("off_variation") != "green" {
+		t.Errorf("the environment was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceFeatureFlagEnvironmentReadDeletedEnvironment(t *testing.T) {
+	server, _ := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"flag_key":    "my-flag",
+		"env_key":     "staging",
+	})
+	d.SetId("my-project:my-flag:staging")
+
+	if err := resourceFeatureFlagEnvironmentRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the resource to be removed from the state, got id (%s)", d.Id())
+	}
+}
+
+func TestResourceFeatureFlagEnvironmentRules(t *testing.T) {
+	server, _ := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key":   "my-project",
+		"flag_key":      "my-flag",
+		"env_key":       "production",
+		"off_variation": "red",
+		"fallthrough": []interface{}{map[string]interface{}{
+			"variation": "blue",
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"variation": "green",
+			"clauses": []interface{}{map[string]interface{}{
+				"attribute":  "age",
+				"operator":   "greaterThanOrEqual",
+				"values":     []interface{}{"18"},
+				"value_type": "number",
+			}},
+		}},
+	})
+
+	if err := resourceFeatureFlagEnvironmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Get("rules.#") != 1 || d.Get("rules.0.variation") != "green" ||
+		d.Get("rules.0.clauses.0.values.0") != "18" || d.Get("rules.0.clauses.0.value_type") != "number" {
+		t.Errorf("the rules were not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceFeatureFlagEnvironmentFallthroughRollout(t *testing.T) {
+	server, document := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key":   "my-project",
+		"flag_key":      "my-flag",
+		"env_key":       "production",
+		"off_variation": "red",
+		"fallthrough": []interface{}{map[string]interface{}{
+			"rollout": []interface{}{map[string]interface{}{
+				"bucket_by": "company",
+				"weighted_variation": []interface{}{
+					map[string]interface{}{"variation": "blue", "weight": 90},
+					map[string]interface{}{"variation": "green", "weight": 10},
+				},
+			}},
+		}},
+	})
+
+	if err := resourceFeatureFlagEnvironmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	fallthroughValue := (*document)["environments"].(map[string]interface{})["production"].(map[string]interface{})["fallthrough"]
+	testPayloadVerify(t, fallthroughValue, `{"rollout": {"bucketBy": "company", "variations": [
+		{"variation": 0, "weight": 90000},
+		{"variation": 1, "weight": 10000}
+	]}}`)
+
+	if d.Get("fallthrough.0.variation") != "" || d.Get("fallthrough.0.rollout.0.weighted_variation.1.weight") != float64(10) {
+		t.Errorf("the fallthrough was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceFeatureFlagEnvironmentTargets(t *testing.T) {
+	server, _ := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key":   "my-project",
+		"flag_key":      "my-flag",
+		"env_key":       "production",
+		"off_variation": "red",
+		"fallthrough": []interface{}{map[string]interface{}{
+			"variation": "blue",
+		}},
+		"targets": []interface{}{map[string]interface{}{
+			"variation": "green",
+			"values":    []interface{}{"qa-2", "qa-1"},
+		}},
+	})
+
+	if err := resourceFeatureFlagEnvironmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	targets := d.Get("targets").(*schema.Set).List()
+	if len(targets) != 1 {
+		t.Fatalf("got %d targets but want 1", len(targets))
+	}
+	target := targets[0].(map[string]interface{})
+	if target["variation"] != "green" || target["values"].(*schema.Set).Len() != 2 {
+		t.Errorf("the targets were not read back as expected: %v", target)
+	}
+}
+
+const testPrerequisiteFlag = `{
+	"key": "prerequisite-flag",
+	"kind": "boolean",
+	"variations": [{"value": true}, {"value": false}]
+}`
+
+func TestResourceFeatureFlagEnvironmentPrerequisites(t *testing.T) {
+	server, document := newTestFlagServer(t, testFlag, testPrerequisiteFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlagEnvironment().Schema, map[string]interface{}{
+		"project_key":   "my-project",
+		"flag_key":      "my-flag",
+		"env_key":       "production",
+		"off_variation": "red",
+		"fallthrough": []interface{}{map[string]interface{}{
+			"variation": "blue",
+		}},
+		"prerequisites": []interface{}{map[string]interface{}{
+			"flag_key":  "prerequisite-flag",
+			"variation": "false",
+		}},
+	})
+
+	if err := resourceFeatureFlagEnvironmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	production := (*document)["environments"].(map[string]interface{})["production"].(map[string]interface{})
+	testPayloadVerify(t, production["prerequisites"], `[{"key": "prerequisite-flag", "variation": 1}]`)
+	if d.Get("prerequisites.0.variation") != "false" {
+		t.Errorf("the prerequisites were not read back as expected: %v", d.State())
+	}
+
+	// A prerequisite removed in the UI shows up as drift
+	production["prerequisites"] = []interface{}{}
+	if err := resourceFeatureFlagEnvironmentRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("prerequisites.#") != 0 {
+		t.Errorf("expected the prerequisites to be empty, got %v", d.Get("prerequisites"))
+	}
+}
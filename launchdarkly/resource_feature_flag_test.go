@@ -0,0 +1,188 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceFeatureFlagCreateEnvironments(t *testing.T) {
+	// LaunchDarkly gives boolean flags declared without variations the true and false variations
+	server, document := newTestResourceServer(t, testResourceServer{
+		path:       "/api/v2/flags/my-project/my-flag",
+		createPath: "/api/v2/flags/my-project",
+		respond: func(r *http.Request, response map[string]interface{}) map[string]interface{} {
+			if variations, _ := response["variations"].([]interface{}); len(variations) == 0 {
+				response["variations"] = []interface{}{
+					map[string]interface{}{"value": true},
+					map[string]interface{}{"value": false},
+				}
+			}
+			return response
+		},
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlag().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "my-flag",
+		"name":        "My Flag",
+		"environment": []interface{}{
+			map[string]interface{}{
+				"key": "production",
+				"on":  true,
+				"rules": []interface{}{map[string]interface{}{
+					"clauses": []interface{}{map[string]interface{}{
+						"attribute": "email",
+						"operator":  "endsWith",
+						"values":    []interface{}{"@example.com"},
+					}},
+					"variation": "false",
+				}},
+				"targets": []interface{}{map[string]interface{}{
+					"variation": "true",
+					"values":    []interface{}{"user-1"},
+				}},
+			},
+		},
+	})
+
+	if err := resourceFeatureFlagCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	production := (*document)["environments"].(map[string]interface{})["production"].(map[string]interface{})
+	if production["on"] != true {
+		t.Errorf("the environment was not turned on: %v", production)
+	}
+	if rules := production["rules"].([]interface{}); len(rules) != 1 || rules[0].(map[string]interface{})["variation"] != float64(1) {
+		t.Errorf("the rules were not patched as expected: %v", production["rules"])
+	}
+	testPayloadVerify(t, production["targets"], `[{"values": ["user-1"], "variation": 0}]`)
+	if d.Get("environment.0.rules.0.variation") != "false" {
+		t.Errorf("the environment was not set in the state: %v", d.State())
+	}
+}
+
+func TestResourceFeatureFlagUpdateEnvironments(t *testing.T) {
+	server, document := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	resource := resourceFeatureFlag()
+	config := map[string]interface{}{
+		"project_key":     "my-project",
+		"key":             "my-flag",
+		"name":            "My Flag",
+		"variations_kind": "string",
+		"variations": []interface{}{
+			map[string]interface{}{"value": "blue"},
+			map[string]interface{}{"value": "green"},
+			map[string]interface{}{"value": "red"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, config)
+	d.SetId("my-flag")
+	if err := resourceFeatureFlagRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The variations in the state resolve the values of the new environment block
+	config["environment"] = []interface{}{
+		map[string]interface{}{
+			"key": "production",
+			"on":  true,
+			"rules": []interface{}{map[string]interface{}{
+				"clauses": []interface{}{map[string]interface{}{
+					"attribute": "country",
+					"operator":  "in",
+					"values":    []interface{}{"ca"},
+				}},
+				"variation": "red",
+			}},
+			"targets": []interface{}{map[string]interface{}{
+				"variation": "green",
+				"values":    []interface{}{"user-1"},
+			}},
+		},
+	}
+	state := d.State()
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(config), newTestClient(server))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	updated, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := resourceFeatureFlagUpdate(updated, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	production := (*document)["environments"].(map[string]interface{})["production"].(map[string]interface{})
+	if production["on"] != true {
+		t.Errorf("the environment was not turned on: %v", production)
+	}
+	if rules := production["rules"].([]interface{}); len(rules) != 1 || rules[0].(map[string]interface{})["variation"] != float64(2) {
+		t.Errorf("the rules were not patched as expected: %v", production["rules"])
+	}
+	testPayloadVerify(t, production["targets"], `[{"values": ["user-1"], "variation": 1}]`)
+}
+
+func TestResourceFeatureFlagReadEnvironments(t *testing.T) {
+	server, _ := newTestFlagServer(t, `{
+		"key": "my-flag",
+		"kind": "boolean",
+		"variations": [{"value": true}, {"value": false}],
+		"environments": {
+			"production": {"on": true, "offVariation": 1, "fallthrough": {"variation": 0}},
+			"staging": {"on": false, "offVariation": 1, "fallthrough": {"variation": 0}}
+		}
+	}`)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceFeatureFlag().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "my-flag",
+		"name":        "My Flag",
+		"environment": []interface{}{
+			map[string]interface{}{"key": "staging", "on": true},
+			map[string]interface{}{"key": "production", "on": false},
+			map[string]interface{}{"key": "deleted", "on": true},
+		},
+	})
+	d.SetId("my-flag")
+
+	if err := resourceFeatureFlagRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Flipping the kill switch in the UI shows up as drift, and deleted environments are dropped
+	if d.Get("environment.#") != 2 {
+		t.Fatalf("got %v environments but want 2", d.Get("environment.#"))
+	}
+	if d.Get("environment.0.key") != "staging" || d.Get("environment.0.on") != false {
+		t.Errorf("unexpected staging environment: %v", d.Get("environment.0"))
+	}
+	if d.Get("environment.1.key") != "production" || d.Get("environment.1.on") != true {
+		t.Errorf("unexpected production environment: %v", d.Get("environment.1"))
+	}
+}
+
+func TestDataSourceFeatureFlagRead(t *testing.T) {
+	server, _ := newTestFlagServer(t, testFlag)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceFeatureFlag().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "my-flag",
+	})
+
+	if err := resourceFeatureFlagRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "my-flag" || d.Get("variations.#") != 3 {
+		t.Errorf("the flag was not read as expected: %v", d.State())
+	}
+}
@@ -0,0 +1,395 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+const METRIC_KIND_CUSTOM = "custom"
+const METRIC_KIND_PAGEVIEW = "pageview"
+const METRIC_KIND_CLICK = "click"
+
+var metricKinds = []string{METRIC_KIND_CUSTOM, METRIC_KIND_PAGEVIEW, METRIC_KIND_CLICK}
+
+const METRIC_URL_KIND_EXACT = "exact"
+const METRIC_URL_KIND_CANONICAL = "canonical"
+const METRIC_URL_KIND_SUBSTRING = "substring"
+const METRIC_URL_KIND_REGEX = "regex"
+
+var metricUrlKinds = []string{METRIC_URL_KIND_EXACT, METRIC_URL_KIND_CANONICAL, METRIC_URL_KIND_SUBSTRING, METRIC_URL_KIND_REGEX}
+
+// The attribute of a URL matcher holding what is matched, by kind of matcher
+var metricUrlAttributes = map[string]string{
+	METRIC_URL_KIND_EXACT:     "url",
+	METRIC_URL_KIND_CANONICAL: "url",
+	METRIC_URL_KIND_SUBSTRING: "substring",
+	METRIC_URL_KIND_REGEX:     "pattern",
+}
+
+var metricSuccessCriteria = []string{"HigherThanBaseline", "LowerThanBaseline"}
+
+// Attributes of a metric that only apply to some kinds of metrics
+var metricKindAttributes = []string{"selector", "urls", "event_key", "is_numeric", "unit", "success_criteria"}
+
+func resourceMetric() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMetricCreate,
+		Read:   resourceMetricRead,
+		Update: resourceMetricUpdate,
+		Delete: resourceMetricDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMetricImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceMetricCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(metricKinds, false),
+			},
+			"selector": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"urls": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(metricUrlKinds, false),
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"substring": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"pattern": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"event_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"is_numeric": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"unit": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"success_criteria": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(metricSuccessCriteria, false),
+			},
+		},
+	}
+}
+
+// The attributes a metric needs depend on its kind. Metrics with values not known yet are checked by
+// LaunchDarkly when applying.
+func resourceMetricCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("kind") {
+		return nil
+	}
+	metric := map[string]interface{}{"kind": d.Get("kind")}
+	for _, attribute := range metricKindAttributes {
+		if !d.NewValueKnown(attribute) {
+			return nil
+		}
+		metric[attribute] = d.Get(attribute)
+	}
+	return validateMetric(metric)
+}
+
+// validateMetric checks that a metric has the attributes required by its kind, and none of those of the
+// other kinds: click metrics need a selector and URL matchers, pageview metrics need URL matchers and
+// custom metrics need an event key, as well as a unit and success criteria when they are numeric.
+func validateMetric(metric map[string]interface{}) error {
+	kind, _ := metric["kind"].(string)
+	selector, _ := metric["selector"].(string)
+	urls := getListOrSet(metric["urls"])
+	eventKey, _ := metric["event_key"].(string)
+	isNumeric, _ := metric["is_numeric"].(bool)
+	unit, _ := metric["unit"].(string)
+	successCriteria, _ := metric["success_criteria"].(string)
+
+	switch kind {
+	case METRIC_KIND_CLICK, METRIC_KIND_PAGEVIEW:
+		if kind == METRIC_KIND_CLICK && len(selector) == 0 {
+			return fmt.Errorf("selector is required for %s metrics", kind)
+		}
+		if kind == METRIC_KIND_PAGEVIEW && len(selector) > 0 {
+			return fmt.Errorf("selector can only be set on %s metrics", METRIC_KIND_CLICK)
+		}
+		if len(urls) == 0 {
+			return fmt.Errorf("urls is required for %s metrics", kind)
+		}
+		if len(eventKey) > 0 || isNumeric || len(unit) > 0 || len(successCriteria) > 0 {
+			return fmt.Errorf("event_key, is_numeric, unit and success_criteria can only be set on %s metrics", METRIC_KIND_CUSTOM)
+		}
+		return validateMetricUrls(urls)
+	case METRIC_KIND_CUSTOM:
+		if len(eventKey) == 0 {
+			return fmt.Errorf("event_key is required for %s metrics", kind)
+		}
+		if len(selector) > 0 || len(urls) > 0 {
+			return fmt.Errorf("selector and urls cannot be set on %s metrics", kind)
+		}
+		if isNumeric && (len(unit) == 0 || len(successCriteria) == 0) {
+			return fmt.Errorf("unit and success_criteria are required for numeric %s metrics", kind)
+		}
+		if !isNumeric && len(unit) > 0 {
+			return fmt.Errorf("unit can only be set on numeric %s metrics", kind)
+		}
+	}
+	return nil
+}
+
+// validateMetricUrls checks that each URL matcher only sets the attribute matching its kind.
+func validateMetricUrls(urls []interface{}) error {
+	for _, rawUrl := range urls {
+		url, ok := rawUrl.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, _ := url["kind"].(string)
+		expected, known := metricUrlAttributes[kind]
+		if !known {
+			continue
+		}
+		for _, attribute := range []string{"url", "substring", "pattern"} {
+			value, _ := url[attribute].(string)
+			if attribute == expected && len(value) == 0 {
+				return fmt.Errorf("%s is required for %s URL matchers", attribute, kind)
+			}
+			if attribute != expected && len(value) > 0 {
+				return fmt.Errorf("%s cannot be set on %s URL matchers", attribute, kind)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceMetricImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return resourceImport(resourceMetricRead, d, meta)
+}
+
+func resourceMetricCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	key := d.Get("key").(string)
+
+	payload := JsonMetric{
+		Key:             key,
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		Kind:            d.Get("kind").(string),
+		Tags:            transformStringSetFromTerraformFormat(d.Get("tags")),
+		Selector:        d.Get("selector").(string),
+		Urls:            transformMetricUrlsFromTerraformFormat(d.Get("urls")),
+		EventKey:        d.Get("event_key").(string),
+		IsNumeric:       d.Get("is_numeric").(bool),
+		Unit:            d.Get("unit").(string),
+		SuccessCriteria: d.Get("success_criteria").(string),
+	}
+
+	err := client.Post(ctx, client.getMetricCreateUrl(project), payload, []int{201}, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(key)
+
+	return resourceMetricRead(d, m)
+}
+
+func resourceMetricRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project_key").(string)
+	key := d.Get("key").(string)
+
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonMetric
+	err := client.GetInto(ctx, client.getMetricUrl(project, key), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(key)
+	d.Set("name", response.Name)
+	d.Set("description", response.Description)
+	d.Set("kind", response.Kind)
+	if err := d.Set("tags", response.Tags); err != nil {
+		return err
+	}
+	d.Set("selector", response.Selector)
+	if err := d.Set("urls", transformMetricUrlsFromLaunchDarklyFormat(response.Urls)); err != nil {
+		return err
+	}
+	d.Set("event_key", response.EventKey)
+	d.Set("is_numeric", response.IsNumeric)
+	d.Set("unit", response.Unit)
+	d.Set("success_criteria", response.SuccessCriteria)
+
+	return nil
+}
+
+func resourceMetricUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	key := d.Get("key").(string)
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/description",
+		"value": d.Get("description").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/tags",
+		"value": transformStringSetFromTerraformFormat(d.Get("tags")),
+	}}
+
+	switch d.Get("kind").(string) {
+	case METRIC_KIND_CLICK, METRIC_KIND_PAGEVIEW:
+		payload = append(payload, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/urls",
+			"value": transformMetricUrlsFromTerraformFormat(d.Get("urls")),
+		})
+		if d.Get("kind").(string) == METRIC_KIND_CLICK {
+			payload = append(payload, map[string]interface{}{
+				"op":    "replace",
+				"path":  "/selector",
+				"value": d.Get("selector").(string),
+			})
+		}
+	case METRIC_KIND_CUSTOM:
+		payload = append(payload, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/eventKey",
+			"value": d.Get("event_key").(string),
+		}, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/isNumeric",
+			"value": d.Get("is_numeric").(bool),
+		}, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/unit",
+			"value": d.Get("unit").(string),
+		}, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/successCriteria",
+			"value": d.Get("success_criteria").(string),
+		})
+	}
+
+	_, err := client.Patch(ctx, client.getMetricUrl(project, key), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceMetricRead(d, m)
+}
+
+func resourceMetricDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	key := d.Get("key").(string)
+
+	err := client.Delete(ctx, client.getMetricUrl(project, key), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func transformMetricUrlsFromTerraformFormat(value interface{}) []JsonMetricUrl {
+	urls := getListOrSet(value)
+	transformed := make([]JsonMetricUrl, len(urls))
+	for index, rawUrl := range urls {
+		url := rawUrl.(map[string]interface{})
+		transformed[index] = JsonMetricUrl{
+			Kind:      url["kind"].(string),
+			Url:       url["url"].(string),
+			Substring: url["substring"].(string),
+			Pattern:   url["pattern"].(string),
+		}
+	}
+	return transformed
+}
+
+func transformMetricUrlsFromLaunchDarklyFormat(urls []JsonMetricUrl) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(urls))
+	for index, url := range urls {
+		transformed[index] = map[string]interface{}{
+			"kind":      url.Kind,
+			"url":       url.Url,
+			"substring": url.Substring,
+			"pattern":   url.Pattern,
+		}
+	}
+	return transformed
+}
@@ -0,0 +1,156 @@
+package launchdarkly
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestValidateMetric(t *testing.T) {
+	pageUrls := []interface{}{map[string]interface{}{
+		"kind":      "substring",
+		"url":       "",
+		"substring": "/checkout",
+		"pattern":   "",
+	}}
+
+	testCases := []struct {
+		name        string
+		metric      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:   "click",
+			metric: map[string]interface{}{"kind": "click", "selector": ".buy", "urls": pageUrls},
+		},
+		{
+			name:        "click without selector",
+			metric:      map[string]interface{}{"kind": "click", "urls": pageUrls},
+			expectedErr: "selector is required for click metrics",
+		},
+		{
+			name:   "pageview",
+			metric: map[string]interface{}{"kind": "pageview", "urls": pageUrls},
+		},
+		{
+			name:        "pageview without urls",
+			metric:      map[string]interface{}{"kind": "pageview", "urls": []interface{}{}},
+			expectedErr: "urls is required for pageview metrics",
+		},
+		{
+			name:        "pageview with selector",
+			metric:      map[string]interface{}{"kind": "pageview", "selector": ".buy", "urls": pageUrls},
+			expectedErr: "selector can only be set on click metrics",
+		},
+		{
+			name: "url matcher without its attribute",
+			metric: map[string]interface{}{"kind": "pageview", "urls": []interface{}{map[string]interface{}{
+				"kind": "regex",
+				"url":  "https://example.com",
+			}}},
+			expectedErr: "cannot be set on regex URL matchers",
+		},
+		{
+			name:   "conversion",
+			metric: map[string]interface{}{"kind": "custom", "event_key": "signed-up"},
+		},
+		{
+			name:        "custom without event key",
+			metric:      map[string]interface{}{"kind": "custom"},
+			expectedErr: "event_key is required for custom metrics",
+		},
+		{
+			name: "numeric",
+			metric: map[string]interface{}{
+				"kind":             "custom",
+				"event_key":        "cart-total",
+				"is_numeric":       true,
+				"unit":             "dollars",
+				"success_criteria": "HigherThanBaseline",
+			},
+		},
+		{
+			name:        "numeric without unit",
+			metric:      map[string]interface{}{"kind": "custom", "event_key": "cart-total", "is_numeric": true, "success_criteria": "HigherThanBaseline"},
+			expectedErr: "unit and success_criteria are required for numeric custom metrics",
+		},
+		{
+			name:        "custom with urls",
+			metric:      map[string]interface{}{"kind": "custom", "event_key": "signed-up", "urls": pageUrls},
+			expectedErr: "selector and urls cannot be set on custom metrics",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateMetric(testCase.metric)
+			if len(testCase.expectedErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+				t.Errorf("got error (%v) but want (%s)", err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestResourceMetricCreate(t *testing.T) {
+	server, document := newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/metrics/my-project",
+		path:       "/api/v2/metrics/my-project/checkout-clicks",
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMetric().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "checkout-clicks",
+		"name":        "Checkout clicks",
+		"kind":        "click",
+		"selector":    ".checkout",
+		"urls": []interface{}{map[string]interface{}{
+			"kind": "exact",
+			"url":  "https://example.com/cart",
+		}},
+	})
+
+	if err := resourceMetricCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "checkout-clicks" {
+		t.Errorf("got id (%s) but want (checkout-clicks)", d.Id())
+	}
+	testPayloadVerify(t, *document, `{
+		"key": "checkout-clicks",
+		"name": "Checkout clicks",
+		"description": "",
+		"kind": "click",
+		"tags": [],
+		"selector": ".checkout",
+		"urls": [{"kind": "exact", "url": "https://example.com/cart"}],
+		"isNumeric": false
+	}`)
+	if d.Get("selector") != ".checkout" || d.Get("urls").(*schema.Set).Len() != 1 {
+		t.Errorf("the metric was not read back as expected: %v", d.State())
+	}
+}
+
+func TestDataSourceMetricReadMissing(t *testing.T) {
+	server, _ := newTestResourceServer(t, testResourceServer{
+		path: "/api/v2/metrics/my-project/checkout-clicks",
+	})
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceMetric().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"key":         "checkout-clicks",
+	})
+
+	if err := dataSourceMetricRead(d, newTestClient(server)); err == nil {
+		t.Error("expected an error for a metric that does not exist")
+	}
+}
@@ -13,6 +13,7 @@ func resourceProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceProjectImport,
 		},
+		Timeouts: defaultResourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -33,13 +34,17 @@ func resourceProjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.
 	d.SetId(d.Id())
 	d.Set("key", d.Id())
 
-	resourceProjectRead(d, meta)
+	if err := resourceProjectRead(d, meta); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	name := d.Get("name").(string)
 	key := d.Get("key").(string)
@@ -50,24 +55,24 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	var response JsonProject
-	err := client.Post(getProjectCreateUrl(), payload, []int{201}, &response)
+	err := client.Post(ctx, client.getProjectCreateUrl(), payload, []int{201}, &response)
 	if err != nil {
 		return err
 	}
 
 	// Default environments will be created, we want to get rid of those
-	environmentKeys, err := getEnvironmentKeys(client, key)
+	environmentKeys, err := getEnvironmentKeys(ctx, client, key)
 	if err != nil {
 		return err
 	}
 
-	err = ensureThereIsADummyEnvironment(client, key)
+	err = ensureThereIsADummyEnvironment(ctx, client, key)
 	if err != nil {
 		return err
 	}
 
 	for _, environmentKey := range environmentKeys {
-		err = client.Delete(getEnvironmentUrl(key, environmentKey), []int{204})
+		err = client.Delete(ctx, client.getEnvironmentUrl(key, environmentKey), []int{204})
 		if err != nil {
 			return err
 		}
@@ -84,13 +89,18 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	key := d.Get("key").(string)
 
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	var response JsonProject
-	err := client.GetInto(getProjectUrl(key), []int{200}, &response)
-	if err != nil {
+	err := client.GetInto(ctx, client.getProjectUrl(key), []int{200}, &response)
+	if isNotFound(err) {
 		d.SetId("")
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 
 	d.SetId(key)
 	d.Set("name", response.Name)
@@ -101,6 +111,8 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	name := d.Get("name").(string)
 
@@ -110,7 +122,7 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 		"value": name,
 	}}
 
-	_, err := client.Patch(getProjectUrl(d.Id()), payload, []int{200}, 0)
+	_, err := client.Patch(ctx, client.getProjectUrl(d.Id()), payload, []int{200})
 	if err != nil {
 		return err
 	}
@@ -120,8 +132,10 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 
 func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
-	err := client.Delete(getProjectUrl(d.Id()), []int{204, 404})
+	err := client.Delete(ctx, client.getProjectUrl(d.Id()), []int{204, 404})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,64 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceProjectRead(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		wantedId string
+		wantErr  bool
+	}{
+		{
+			name:     "existing project",
+			status:   200,
+			body:     `{"key":"my-project","name":"My Project"}`,
+			wantedId: "my-project",
+		},
+		{
+			name:     "deleted project is removed from the state",
+			status:   404,
+			wantedId: "",
+		},
+		{
+			name:     "server error keeps the project in the state",
+			status:   500,
+			wantedId: "my-project",
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(server)
+			client.MaxRetries = 0
+
+			d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+				"key":  "my-project",
+				"name": "My Project",
+			})
+			d.SetId("my-project")
+
+			err := resourceProjectRead(d, client)
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if d.Id() != testCase.wantedId {
+				t.Errorf("got id (%s) but want (%s)", d.Id(), testCase.wantedId)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package launchdarkly
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceSegment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSegmentCreate,
+		Read:   resourceSegmentRead,
+		Update: resourceSegmentUpdate,
+		Delete: resourceSegmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSegmentImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceSegmentCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"env_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateKey,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"included": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"excluded": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clauses": clausesSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSegmentCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	rules, _ := d.Get("rules").([]interface{})
+	for _, rawRule := range rules {
+		if rule, ok := rawRule.(map[string]interface{}); ok {
+			clauses, _ := rule["clauses"].([]interface{})
+			if err := validateClauses(clauses); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getSegmentId(project string, environment string, segment string) string {
+	return fmt.Sprintf("%s:%s:%s", project, environment, segment)
+}
+
+func resourceSegmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	project, environment, segment, err := parseThreePartID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("project_key", project)
+	d.Set("env_key", environment)
+	d.Set("key", segment)
+
+	if err := resourceSegmentRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSegmentCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	environment := d.Get("env_key").(string)
+	key := d.Get("key").(string)
+
+	payload := JsonSegment{
+		Name:        d.Get("name").(string),
+		Key:         key,
+		Description: d.Get("description").(string),
+		Tags:        transformStringSetFromTerraformFormat(d.Get("tags")),
+	}
+
+	err := client.Post(ctx, client.getSegmentCreateUrl(project, environment), payload, []int{201}, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(getSegmentId(project, environment, key))
+
+	// The users and rules of a segment can only be set by patching it
+	patchPayload, err := createPayloadForSegmentTargeting(d)
+	if err != nil {
+		return err
+	}
+	_, err = client.Patch(ctx, client.getSegmentUrl(project, environment, key), patchPayload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceSegmentRead(d, m)
+}
+
+func resourceSegmentRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project_key").(string)
+	environment := d.Get("env_key").(string)
+	key := d.Get("key").(string)
+
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonSegment
+	err := client.GetInto(ctx, client.getSegmentUrl(project, environment, key), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(getSegmentId(project, environment, key))
+	d.Set("name", response.Name)
+	d.Set("description", response.Description)
+	if err := d.Set("tags", response.Tags); err != nil {
+		return err
+	}
+	if err := d.Set("included", response.Included); err != nil {
+		return err
+	}
+	if err := d.Set("excluded", response.Excluded); err != nil {
+		return err
+	}
+	if err := d.Set("rules", transformSegmentRulesFromLaunchDarklyFormat(response.Rules)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceSegmentUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	environment := d.Get("env_key").(string)
+	key := d.Get("key").(string)
+
+	mainPayload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/description",
+		"value": d.Get("description").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/tags",
+		"value": transformStringSetFromTerraformFormat(d.Get("tags")),
+	}}
+
+	targetingPayload, err := createPayloadForSegmentTargeting(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Patch(ctx, client.getSegmentUrl(project, environment, key), append(mainPayload, targetingPayload...), []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceSegmentRead(d, m)
+}
+
+func resourceSegmentDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	project := d.Get("project_key").(string)
+	environment := d.Get("env_key").(string)
+	key := d.Get("key").(string)
+
+	err := client.Delete(ctx, client.getSegmentUrl(project, environment, key), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createPayloadForSegmentTargeting(d *schema.ResourceData) ([]map[string]interface{}, error) {
+	rules, err := transformSegmentRulesFromTerraformFormat(d.Get("rules").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/included",
+		"value": transformStringSetFromTerraformFormat(d.Get("included")),
+	}, {
+		"op":    "replace",
+		"path":  "/excluded",
+		"value": transformStringSetFromTerraformFormat(d.Get("excluded")),
+	}, {
+		"op":    "replace",
+		"path":  "/rules",
+		"value": rules,
+	}}, nil
+}
+
+func transformSegmentRulesFromTerraformFormat(rules []interface{}) ([]JsonSegmentRule, error) {
+	transformed := make([]JsonSegmentRule, len(rules))
+	for index, rawRule := range rules {
+		rule := rawRule.(map[string]interface{})
+
+		clauses, err := transformClausesFromTerraformFormat(rule["clauses"].([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		transformed[index] = JsonSegmentRule{Clauses: clauses}
+	}
+	return transformed, nil
+}
+
+func transformSegmentRulesFromLaunchDarklyFormat(rules []JsonSegmentRule) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(rules))
+	for index, rule := range rules {
+		transformed[index] = map[string]interface{}{
+			"clauses": transformClausesFromLaunchDarklyFormat(rule.Clauses),
+		}
+	}
+	return transformed
+}
@@ -0,0 +1,97 @@
+package launchdarkly
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func newTestSegmentServer(t *testing.T) (*httptest.Server, *map[string]interface{}) {
+	return newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/segments/my-project/production",
+		path:       "/api/v2/segments/my-project/production/beta-testers",
+	})
+}
+
+func TestResourceSegmentCreate(t *testing.T) {
+	server, document := newTestSegmentServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceSegment().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"env_key":     "production",
+		"key":         "beta-testers",
+		"name":        "Beta testers",
+		"tags":        []interface{}{"beta", "qa"},
+		"included":    []interface{}{"user-2", "user-1"},
+		"excluded":    []interface{}{"user-3"},
+		"rules": []interface{}{map[string]interface{}{
+			"clauses": []interface{}{map[string]interface{}{
+				"attribute": "email",
+				"operator":  "endsWith",
+				"values":    []interface{}{"@example.com"},
+			}},
+		}},
+	})
+
+	if err := resourceSegmentCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "my-project:production:beta-testers" {
+		t.Errorf("got id (%s) but want (my-project:production:beta-testers)", d.Id())
+	}
+	testPayloadVerify(t, (*document)["included"], `["user-1", "user-2"]`)
+	testPayloadVerify(t, (*document)["rules"], `[{"clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"], "negate": false}]}]`)
+
+	if d.Get("included").(*schema.Set).Len() != 2 || d.Get("rules.0.clauses.0.operator") != "endsWith" {
+		t.Errorf("the segment was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceSegmentImport(t *testing.T) {
+	server, _ := newTestSegmentServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceSegment().Schema, map[string]interface{}{})
+	d.SetId("my-project:production")
+
+	if _, err := resourceSegmentImport(d, newTestClient(server)); err == nil {
+		t.Error("expected an error for an import ID with two parts")
+	}
+}
+
+func TestResourceSegmentReadDeleted(t *testing.T) {
+	server, _ := newTestSegmentServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceSegment().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"env_key":     "production",
+		"key":         "beta-testers",
+	})
+	d.SetId("my-project:production:beta-testers")
+
+	if err := resourceSegmentRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the segment to be removed from the state, got id (%s)", d.Id())
+	}
+}
+
+func TestDataSourceSegmentReadMissing(t *testing.T) {
+	server, _ := newTestSegmentServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceSegment().Schema, map[string]interface{}{
+		"project_key": "my-project",
+		"env_key":     "production",
+		"key":         "beta-testers",
+	})
+
+	if err := dataSourceSegmentRead(d, newTestClient(server)); err == nil {
+		t.Error("expected an error for a segment that does not exist")
+	}
+}
@@ -0,0 +1,196 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+const MEMBER_ROLE_READER = "reader"
+const MEMBER_ROLE_WRITER = "writer"
+const MEMBER_ROLE_ADMIN = "admin"
+
+// The owner role cannot be given through the API
+var memberRoles = []string{MEMBER_ROLE_READER, MEMBER_ROLE_WRITER, MEMBER_ROLE_ADMIN}
+
+func resourceTeamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeamMemberCreate,
+		Read:   resourceTeamMemberRead,
+		Update: resourceTeamMemberUpdate,
+		Delete: resourceTeamMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTeamMemberImport,
+		},
+		Timeouts: defaultResourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"first_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// LaunchDarkly gives the reader role to members without a role, including those with custom roles
+			"role": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringInSlice(memberRoles, false),
+				ConflictsWith: []string{"custom_roles"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return len(new) == 0 && old == MEMBER_ROLE_READER
+				},
+			},
+			"custom_roles": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"role"},
+			},
+		},
+	}
+}
+
+func resourceTeamMemberImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTeamMemberRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTeamMemberCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	email := d.Get("email").(string)
+
+	// Members are invited in batches, we send a batch of one
+	payload := []JsonMember{{
+		Email:       email,
+		FirstName:   d.Get("first_name").(string),
+		LastName:    d.Get("last_name").(string),
+		Role:        d.Get("role").(string),
+		CustomRoles: transformStringSetFromTerraformFormat(d.Get("custom_roles")),
+	}}
+
+	var response JsonMembers
+	err := client.Post(ctx, client.getMemberCreateUrl(), payload, []int{201}, &response)
+	if err != nil {
+		return err
+	}
+	if len(response.Items) != 1 {
+		return fmt.Errorf("inviting %s returned %d members instead of one", email, len(response.Items))
+	}
+
+	d.SetId(response.Items[0].Id)
+
+	return resourceTeamMemberRead(d, m)
+}
+
+func resourceTeamMemberRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonMember
+	err := client.GetInto(ctx, client.getMemberUrl(d.Id()), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return setTeamMember(ctx, client, d, response)
+}
+
+func resourceTeamMemberUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	customRoles := transformStringSetFromTerraformFormat(d.Get("custom_roles"))
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/firstName",
+		"value": d.Get("first_name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/lastName",
+		"value": d.Get("last_name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/customRoles",
+		"value": customRoles,
+	}}
+
+	// Members with custom roles or without a role in the configuration have the reader role, the built-in
+	// role they had before is reset so that it does not stay hidden behind the reader role.
+	role := d.Get("role").(string)
+	if len(customRoles) > 0 || len(role) == 0 {
+		role = MEMBER_ROLE_READER
+	}
+	payload = append(payload, map[string]interface{}{
+		"op":    "replace",
+		"path":  "/role",
+		"value": role,
+	})
+
+	_, err := client.Patch(ctx, client.getMemberUrl(d.Id()), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceTeamMemberRead(d, m)
+}
+
+func resourceTeamMemberDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	err := client.Delete(ctx, client.getMemberUrl(d.Id()), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setTeamMember sets the attributes of a team member resource or data source. LaunchDarkly returns the
+// IDs of the custom roles, they are resolved to the keys used in the configuration.
+func setTeamMember(ctx context.Context, client Client, d *schema.ResourceData, member JsonMember) error {
+	customRoles := make([]string, len(member.CustomRoles))
+	for index, customRoleId := range member.CustomRoles {
+		var customRole JsonCustomRole
+		if err := client.GetInto(ctx, client.getCustomRoleUrl(customRoleId), []int{200}, &customRole); err != nil {
+			return err
+		}
+		customRoles[index] = customRole.Key
+	}
+
+	d.SetId(member.Id)
+	d.Set("email", member.Email)
+	d.Set("first_name", member.FirstName)
+	d.Set("last_name", member.LastName)
+	d.Set("role", member.Role)
+	if err := d.Set("custom_roles", customRoles); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package launchdarkly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceTeamMemberCreate(t *testing.T) {
+	var invited []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/members":
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &invited)
+			w.WriteHeader(201)
+			w.Write([]byte(`{"items": [{"_id": "member-id", "email": "jane@example.com"}]}`))
+		case "/api/v2/members/member-id":
+			w.WriteHeader(200)
+			w.Write([]byte(`{"_id": "member-id", "email": "jane@example.com", "firstName": "Jane", "role": "reader", "customRoles": ["role-id"]}`))
+		case "/api/v2/roles/role-id":
+			w.WriteHeader(200)
+			w.Write([]byte(`{"key": "flag-maintainers", "name": "Flag maintainers"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceTeamMember().Schema, map[string]interface{}{
+		"email":        "jane@example.com",
+		"first_name":   "Jane",
+		"custom_roles": []interface{}{"flag-maintainers"},
+	})
+
+	if err := resourceTeamMemberCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	testPayloadVerify(t, invited, `[{"email": "jane@example.com", "firstName": "Jane", "customRoles": ["flag-maintainers"]}]`)
+	if d.Id() != "member-id" {
+		t.Errorf("got id (%s) but want (member-id)", d.Id())
+	}
+	customRoles := d.Get("custom_roles").(*schema.Set)
+	if customRoles.Len() != 1 || !customRoles.Contains("flag-maintainers") || d.Get("role") != "reader" {
+		t.Errorf("the member was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceTeamMemberUpdateToCustomRoles(t *testing.T) {
+	server, document := newTestResourceServer(t, testResourceServer{
+		path: "/api/v2/members/member-id",
+		document: map[string]interface{}{
+			"_id":         "member-id",
+			"email":       "jane@example.com",
+			"role":        "admin",
+			"customRoles": []interface{}{},
+		},
+		handle: func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/roles/flag-maintainers" {
+				w.WriteHeader(404)
+				return
+			}
+			w.WriteHeader(200)
+			w.Write([]byte(`{"key": "flag-maintainers", "name": "Flag maintainers"}`))
+		},
+	})
+	defer server.Close()
+
+	resource := resourceTeamMember()
+	state := &terraform.InstanceState{
+		ID: "member-id",
+		Attributes: map[string]string{
+			"id":             "member-id",
+			"email":          "jane@example.com",
+			"role":           "admin",
+			"custom_roles.#": "0",
+		},
+	}
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":        "jane@example.com",
+		"custom_roles": []interface{}{"flag-maintainers"},
+	}), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := resourceTeamMemberUpdate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if (*document)["role"] != "reader" {
+		t.Errorf("got role (%v) but want (reader)", (*document)["role"])
+	}
+	if d.Get("role") != "reader" || !d.Get("custom_roles").(*schema.Set).Contains("flag-maintainers") {
+		t.Errorf("the member was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceTeamMemberRemoveCustomRoles(t *testing.T) {
+	// The admin role given before the custom roles is hidden behind them
+	server, document := newTestResourceServer(t, testResourceServer{
+		path: "/api/v2/members/member-id",
+		document: map[string]interface{}{
+			"_id":         "member-id",
+			"email":       "jane@example.com",
+			"role":        "admin",
+			"customRoles": []interface{}{"role-id"},
+		},
+		handle: func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/roles/role-id" {
+				w.WriteHeader(404)
+				return
+			}
+			w.WriteHeader(200)
+			w.Write([]byte(`{"key": "flag-maintainers", "name": "Flag maintainers"}`))
+		},
+	})
+	defer server.Close()
+
+	resource := resourceTeamMember()
+	current := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"email":        "jane@example.com",
+		"custom_roles": []interface{}{"flag-maintainers"},
+	})
+	current.SetId("member-id")
+	if err := resourceTeamMemberRead(current, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state := current.State()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email": "jane@example.com",
+	})
+	diff, err := resource.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := resourceTeamMemberUpdate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if (*document)["role"] != "reader" || len((*document)["customRoles"].([]interface{})) != 0 {
+		t.Errorf("the member was not patched as expected: %v", *document)
+	}
+	// The reader role given by LaunchDarkly does not show up as a change
+	diff, err = resource.Diff(d.State(), config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("unexpected changes after the update: %v", diff)
+	}
+}
+
+func TestDataSourceTeamMemberRead(t *testing.T) {
+	// Three pages of two members
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		members := make([]JsonMember, 0)
+		for index := offset; index < offset+2 && index < 6; index++ {
+			members = append(members, JsonMember{Id: strconv.Itoa(index), Email: fmt.Sprintf("member-%d@example.com", index)})
+		}
+		response, _ := json.Marshal(JsonMembers{Items: members, TotalCount: 6})
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		email    string
+		wantedId string
+		wantErr  bool
+	}{
+		{name: "first page", email: "member-0@example.com", wantedId: "0"},
+		{name: "last page", email: "member-5@example.com", wantedId: "5"},
+		{name: "unknown member", email: "nobody@example.com", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceTeamMember().Schema, map[string]interface{}{
+				"email": testCase.email,
+			})
+
+			client := newTestClient(server)
+			member, err := getMemberByEmail(context.Background(), client, testCase.email)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if (member == nil) != testCase.wantErr {
+				t.Fatalf("got member (%v) but wanted error: %v", member, testCase.wantErr)
+			}
+
+			err = dataSourceTeamMemberRead(d, client)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if d.Id() != testCase.wantedId {
+				t.Errorf("got id (%s) but want (%s)", d.Id(), testCase.wantedId)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package launchdarkly
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWebhookCreate,
+		Read:   resourceWebhookRead,
+		Update: resourceWebhookUpdate,
+		Delete: resourceWebhookDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceWebhookImport,
+		},
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: resourceWebhookCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateWebhookUrl,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// LaunchDarkly does not return the secret, changes made outside of Terraform are not detected
+			"secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"on": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"policy_statements": policyStatementsSchema(),
+		},
+	}
+}
+
+func resourceWebhookCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	return validatePolicyStatements(d.Get("policy_statements"))
+}
+
+func resourceWebhookImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceWebhookRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	secret := d.Get("secret").(string)
+
+	statements, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy_statements"))
+	if err != nil {
+		return err
+	}
+
+	payload := JsonWebhook{
+		Url:        d.Get("url").(string),
+		Name:       d.Get("name").(string),
+		Secret:     secret,
+		Sign:       len(secret) > 0,
+		On:         d.Get("on").(bool),
+		Tags:       transformStringSetFromTerraformFormat(d.Get("tags")),
+		Statements: statements,
+	}
+
+	var response JsonWebhook
+	err = client.Post(ctx, client.getWebhookCreateUrl(), payload, []int{201}, &response)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(response.Id)
+
+	return resourceWebhookRead(d, m)
+}
+
+func resourceWebhookRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	var response JsonWebhook
+	err := client.GetInto(ctx, client.getWebhookUrl(d.Id()), []int{200}, &response)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("url", response.Url)
+	d.Set("name", response.Name)
+	d.Set("on", response.On)
+	if err := d.Set("tags", response.Tags); err != nil {
+		return err
+	}
+	if err := d.Set("policy_statements", transformPolicyStatementsFromLaunchDarklyFormat(response.Statements)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	statements, err := transformPolicyStatementsFromTerraformFormat(d.Get("policy_statements"))
+	if err != nil {
+		return err
+	}
+
+	payload := []map[string]interface{}{{
+		"op":    "replace",
+		"path":  "/url",
+		"value": d.Get("url").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/name",
+		"value": d.Get("name").(string),
+	}, {
+		"op":    "replace",
+		"path":  "/on",
+		"value": d.Get("on").(bool),
+	}, {
+		"op":    "replace",
+		"path":  "/tags",
+		"value": transformStringSetFromTerraformFormat(d.Get("tags")),
+	}, {
+		"op":    "replace",
+		"path":  "/statements",
+		"value": statements,
+	}}
+
+	if d.HasChange("secret") {
+		secret := d.Get("secret").(string)
+		payload = append(payload, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/secret",
+			"value": secret,
+		}, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/sign",
+			"value": len(secret) > 0,
+		})
+	}
+
+	_, err = client.Patch(ctx, client.getWebhookUrl(d.Id()), payload, []int{200})
+	if err != nil {
+		return err
+	}
+
+	return resourceWebhookRead(d, m)
+}
+
+func resourceWebhookDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(Client)
+	ctx, cancel := client.newContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	err := client.Delete(ctx, client.getWebhookUrl(d.Id()), []int{204, 404})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// newTestWebhookServer never returns the secret, like LaunchDarkly.
+func newTestWebhookServer(t *testing.T) (*httptest.Server, *map[string]interface{}) {
+	return newTestResourceServer(t, testResourceServer{
+		createPath: "/api/v2/webhooks",
+		path:       "/api/v2/webhooks/5e1e8a5c4f0bd20831d8a5f6",
+		id:         "5e1e8a5c4f0bd20831d8a5f6",
+		respond: func(r *http.Request, response map[string]interface{}) map[string]interface{} {
+			delete(response, "secret")
+			return response
+		},
+	})
+}
+
+func TestResourceWebhookCreate(t *testing.T) {
+	server, document := newTestWebhookServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceWebhook().Schema, map[string]interface{}{
+		"url":    "https://audit.example.com/launchdarkly",
+		"name":   "Audit",
+		"secret": "shh",
+		"tags":   []interface{}{"audit"},
+		"policy_statements": []interface{}{map[string]interface{}{
+			"effect":    "allow",
+			"resources": []interface{}{"proj/*:env/production:flag/*"},
+			"actions":   []interface{}{"*"},
+		}},
+	})
+
+	if err := resourceWebhookCreate(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "5e1e8a5c4f0bd20831d8a5f6" {
+		t.Errorf("got id (%s) but want (5e1e8a5c4f0bd20831d8a5f6)", d.Id())
+	}
+	if (*document)["sign"] != true || (*document)["secret"] != "shh" {
+		t.Errorf("the webhook was not signed with the secret: %v", *document)
+	}
+	if d.Get("secret") != "shh" || d.Get("on") != true || d.Get("policy_statements").(*schema.Set).Len() != 1 {
+		t.Errorf("the webhook was not read back as expected: %v", d.State())
+	}
+}
+
+func TestResourceWebhookReadDeleted(t *testing.T) {
+	server, _ := newTestWebhookServer(t)
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceWebhook().Schema, map[string]interface{}{
+		"url": "https://audit.example.com/launchdarkly",
+	})
+	d.SetId("5e1e8a5c4f0bd20831d8a5f6")
+
+	if err := resourceWebhookRead(d, newTestClient(server)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the webhook to be removed from the state, got id (%s)", d.Id())
+	}
+}
@@ -0,0 +1,134 @@
+package launchdarkly
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const defaultMaxRetries = 5
+const defaultMaxRetryWait = 60 * time.Second
+const baseRetryWait = time.Second
+
+// isIdempotentMethod tells whether a request can be sent again without changing its outcome. A POST or a
+// PATCH that failed ambiguously may have been applied already, and sending it again would e.g. create a
+// duplicate or remove another variation through a positional JSON patch.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// LaunchDarkly answers with 429 when a rate limit is hit, before handling the request, so any request can
+// be retried. 5xx errors are usually transient, but the request may have been applied, so only idempotent
+// requests are retried. 501 is excluded since retrying a request that the server does not implement will
+// never succeed.
+func isRetryableStatus(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500 && status != http.StatusNotImplemented && isIdempotentMethod(method)
+}
+
+// isRetryableError tells whether a request that failed with a network error can be sent again. A refused
+// connection means that the request never reached the server, the other transient errors may happen after
+// the server received it.
+func isRetryableError(method string, err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	if !isIdempotentMethod(method) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
+
+// getRetryWait returns how long to wait before the given retry attempt (starting at 0). When rate limited,
+// the delay requested by the server through Retry-After or X-Ratelimit-Reset is honored. LaunchDarkly
+// sends X-Ratelimit-Reset on every response, so it is ignored for server errors, and a requested delay
+// that is already over (e.g. clock skew) falls back to a jittered exponential backoff as well. The result
+// never exceeds maxWait.
+func getRetryWait(attempt int, status int, header http.Header, maxWait time.Duration, now time.Time) time.Duration {
+	wait, found := time.Duration(0), false
+	if status == http.StatusTooManyRequests {
+		wait, found = getServerRequestedWait(header, now)
+	}
+	if !found || wait <= 0 {
+		backoff := baseRetryWait << uint(attempt)
+		if backoff <= 0 || backoff > maxWait {
+			backoff = maxWait
+		}
+		// Spread the retries of concurrent operations between half and the full backoff
+		wait = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	}
+
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
+
+func getServerRequestedWait(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+
+	if retryAfter := header.Get("Retry-After"); len(retryAfter) > 0 {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(date.Sub(now)), true
+		}
+	}
+
+	// LaunchDarkly sends the time at which the current rate limit window resets, in epoch milliseconds
+	if reset := header.Get("X-Ratelimit-Reset"); len(reset) > 0 {
+		if milliseconds, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return nonNegative(time.Unix(0, milliseconds*int64(time.Millisecond)).Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+// sleep waits for the given duration, unless the context is done first.
+func sleep(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func nonNegative(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+	return duration
+}
@@ -0,0 +1,164 @@
+package launchdarkly
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableStatus(t *testing.T) {
+	testCases := []struct {
+		method string
+		status int
+		wanted bool
+	}{
+		{method: "GET", status: 200, wanted: false},
+		{method: "GET", status: 400, wanted: false},
+		{method: "GET", status: 404, wanted: false},
+		{method: "GET", status: 429, wanted: true},
+		{method: "GET", status: 500, wanted: true},
+		{method: "GET", status: 501, wanted: false},
+		{method: "PUT", status: 502, wanted: true},
+		{method: "DELETE", status: 503, wanted: true},
+		{method: "POST", status: 429, wanted: true},
+		{method: "POST", status: 503, wanted: false},
+		{method: "PATCH", status: 429, wanted: true},
+		{method: "PATCH", status: 500, wanted: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.method+" "+strconv.Itoa(testCase.status), func(t *testing.T) {
+			if got := isRetryableStatus(testCase.method, testCase.status); got != testCase.wanted {
+				t.Errorf("got (%v) but want (%v)", got, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		err    error
+		wanted bool
+	}{
+		{name: "unexpected EOF", method: "GET", err: io.ErrUnexpectedEOF, wanted: true},
+		{name: "connection reset", method: "DELETE", err: syscall.ECONNRESET, wanted: true},
+		{name: "connection refused", method: "GET", err: syscall.ECONNREFUSED, wanted: true},
+		{name: "other error", method: "GET", err: errors.New("unsupported protocol scheme"), wanted: false},
+		{name: "POST connection reset", method: "POST", err: syscall.ECONNRESET, wanted: false},
+		{name: "PATCH unexpected EOF", method: "PATCH", err: io.ErrUnexpectedEOF, wanted: false},
+		{name: "POST connection refused", method: "POST", err: syscall.ECONNREFUSED, wanted: true},
+		{name: "PATCH connection refused", method: "PATCH", err: syscall.ECONNREFUSED, wanted: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := isRetryableError(testCase.method, testCase.err); got != testCase.wanted {
+				t.Errorf("got (%v) but want (%v)", got, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestGetRetryWait(t *testing.T) {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	maxWait := time.Minute
+
+	testCases := []struct {
+		name      string
+		attempt   int
+		status    int
+		header    http.Header
+		wantedMin time.Duration
+		wantedMax time.Duration
+	}{
+		{
+			name:      "first backoff",
+			attempt:   0,
+			wantedMin: 500 * time.Millisecond,
+			wantedMax: time.Second,
+		},
+		{
+			name:      "third backoff",
+			attempt:   2,
+			wantedMin: 2 * time.Second,
+			wantedMax: 4 * time.Second,
+		},
+		{
+			name:      "backoff capped at max wait",
+			attempt:   40,
+			wantedMin: maxWait / 2,
+			wantedMax: maxWait,
+		},
+		{
+			name:      "with Retry-After in seconds",
+			attempt:   0,
+			status:    429,
+			header:    http.Header{"Retry-After": []string{"10"}},
+			wantedMin: 10 * time.Second,
+			wantedMax: 10 * time.Second,
+		},
+		{
+			name:      "with Retry-After as a date",
+			attempt:   0,
+			status:    429,
+			header:    http.Header{"Retry-After": []string{now.Add(20 * time.Second).Format(http.TimeFormat)}},
+			wantedMin: 20 * time.Second,
+			wantedMax: 20 * time.Second,
+		},
+		{
+			name:      "with X-Ratelimit-Reset",
+			attempt:   3,
+			status:    429,
+			header:    http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(now.Add(5*time.Second).UnixNano()/int64(time.Millisecond), 10)}},
+			wantedMin: 5 * time.Second,
+			wantedMax: 5 * time.Second,
+		},
+		{
+			name:      "with X-Ratelimit-Reset in the past",
+			attempt:   2,
+			status:    429,
+			header:    http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(now.Add(-5*time.Second).UnixNano()/int64(time.Millisecond), 10)}},
+			wantedMin: 2 * time.Second,
+			wantedMax: 4 * time.Second,
+		},
+		{
+			name:      "with X-Ratelimit-Reset on a server error",
+			attempt:   0,
+			status:    503,
+			header:    http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(now.Add(30*time.Second).UnixNano()/int64(time.Millisecond), 10)}},
+			wantedMin: 500 * time.Millisecond,
+			wantedMax: time.Second,
+		},
+		{
+			name:      "with Retry-After on a server error",
+			attempt:   0,
+			status:    503,
+			header:    http.Header{"Retry-After": []string{"30"}},
+			wantedMin: 500 * time.Millisecond,
+			wantedMax: time.Second,
+		},
+		{
+			name:      "with Retry-After above max wait",
+			attempt:   0,
+			status:    429,
+			header:    http.Header{"Retry-After": []string{"3600"}},
+			wantedMin: maxWait,
+			wantedMax: maxWait,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			wait := getRetryWait(testCase.attempt, testCase.status, testCase.header, maxWait, now)
+			if wait < testCase.wantedMin || wait > testCase.wantedMax {
+				t.Errorf("got wait (%s) but want between (%s) and (%s)", wait, testCase.wantedMin, testCase.wantedMax)
+			}
+		})
+	}
+}
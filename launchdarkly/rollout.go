@@ -0,0 +1,170 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// LaunchDarkly expresses rollout weights in thousandths of a percent, so that 100% is 100000
+const ROLLOUT_WEIGHT_SCALE = 1000
+const ROLLOUT_TOTAL_WEIGHT = 100 * ROLLOUT_WEIGHT_SCALE
+
+func rolloutSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"bucket_by": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"weighted_variation": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"variation": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validateVariationValue,
+							},
+							"weight": {
+								Type:         schema.TypeFloat,
+								Required:     true,
+								ValidateFunc: validation.FloatBetween(0, 100),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func transformRolloutFromTerraformFormat(rollout map[string]interface{}, variations []interface{}) (*JsonRollout, error) {
+	if err := validateRollout(rollout); err != nil {
+		return nil, err
+	}
+
+	weightedVariations := rollout["weighted_variation"].([]interface{})
+	transformed := &JsonRollout{
+		Variations: make([]JsonWeightedVariation, len(weightedVariations)),
+		BucketBy:   rollout["bucket_by"].(string),
+	}
+	for index, rawWeightedVariation := range weightedVariations {
+		weightedVariation := rawWeightedVariation.(map[string]interface{})
+
+		variationIndex, err := getVariationIndex(variations, weightedVariation["variation"].(string))
+		if err != nil {
+			return nil, err
+		}
+
+		transformed.Variations[index] = JsonWeightedVariation{
+			Variation: variationIndex,
+			Weight:    transformRolloutWeightFromTerraformFormat(weightedVariation["weight"].(float64)),
+		}
+	}
+	return transformed, nil
+}
+
+func transformRolloutFromLaunchDarklyFormat(rollout *JsonRollout, variations []JsonVariations) []map[string]interface{} {
+	if rollout == nil {
+		return []map[string]interface{}{}
+	}
+
+	weightedVariations := make([]map[string]interface{}, len(rollout.Variations))
+	for index, weightedVariation := range rollout.Variations {
+		variationIndex := weightedVariation.Variation
+		weightedVariations[index] = map[string]interface{}{
+			"variation": getVariationValue(variations, &variationIndex),
+			"weight":    float64(weightedVariation.Weight) / ROLLOUT_WEIGHT_SCALE,
+		}
+	}
+
+	return []map[string]interface{}{{
+		"bucket_by":          rollout.BucketBy,
+		"weighted_variation": weightedVariations,
+	}}
+}
+
+func transformRolloutWeightFromTerraformFormat(weight float64) int {
+	return int(math.Round(weight * ROLLOUT_WEIGHT_SCALE))
+}
+
+// getRollout returns the rollout block of a fallthrough or of a rule, or nil when there is none.
+func getRollout(block map[string]interface{}) map[string]interface{} {
+	rollouts, _ := block["rollout"].([]interface{})
+	if len(rollouts) == 0 {
+		return nil
+	}
+	rollout, _ := rollouts[0].(map[string]interface{})
+	return rollout
+}
+
+// validateRollout checks that the weights of a rollout add up to 100%, once converted to the
+// precision supported by LaunchDarkly.
+func validateRollout(rollout map[string]interface{}) error {
+	weightedVariations, _ := rollout["weighted_variation"].([]interface{})
+
+	total := 0
+	for _, rawWeightedVariation := range weightedVariations {
+		weightedVariation, ok := rawWeightedVariation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		weight, _ := weightedVariation["weight"].(float64)
+		total += transformRolloutWeightFromTerraformFormat(weight)
+	}
+
+	if total != ROLLOUT_TOTAL_WEIGHT {
+		return fmt.Errorf("the weights of a rollout must add up to 100, got %v", float64(total)/ROLLOUT_WEIGHT_SCALE)
+	}
+	return nil
+}
+
+// validateVariationOrRollout checks that a fallthrough or a rule serves either a single variation or a
+// percentage rollout. Blocks that only allow a single variation are not checked for an empty one.
+func validateVariationOrRollout(block map[string]interface{}, variationKey string, variationRequired bool) error {
+	variation, _ := block[variationKey].(string)
+	rollout := getRollout(block)
+
+	if len(variation) > 0 && rollout != nil {
+		if variation == hcl2shim.UnknownVariableValue {
+			return nil
+		}
+		return fmt.Errorf("%s and rollout cannot be set at the same time", variationKey)
+	}
+	if len(variation) == 0 && rollout == nil && variationRequired {
+		return fmt.Errorf("one of %s or rollout must be set", variationKey)
+	}
+	if rollout != nil {
+		return validateRollout(rollout)
+	}
+	return nil
+}
+
+// transformVariationOrRolloutFromTerraformFormat resolves the variation or the rollout served by a
+// fallthrough or a rule.
+func transformVariationOrRolloutFromTerraformFormat(block map[string]interface{}, variationKey string, variations []interface{}) (*int, *JsonRollout, error) {
+	if rollout := getRollout(block); rollout != nil {
+		transformedRollout, err := transformRolloutFromTerraformFormat(rollout, variations)
+		return nil, transformedRollout, err
+	}
+
+	variation, _ := block[variationKey].(string)
+	if len(variation) == 0 {
+		return nil, nil, fmt.Errorf("one of %s or rollout must be set", variationKey)
+	}
+	variationIndex, err := getVariationIndex(variations, variation)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &variationIndex, nil, nil
+}
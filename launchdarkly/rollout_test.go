@@ -0,0 +1,107 @@
+package launchdarkly
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testRollout(weights ...float64) map[string]interface{} {
+	weightedVariations := make([]interface{}, len(weights))
+	for index, weight := range weights {
+		weightedVariations[index] = map[string]interface{}{
+			"variation": testVariations[index].(map[string]interface{})["value"],
+			"weight":    weight,
+		}
+	}
+	return map[string]interface{}{
+		"bucket_by":          "",
+		"weighted_variation": weightedVariations,
+	}
+}
+
+func TestTransformRolloutFromTerraformFormat(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rollout map[string]interface{}
+		wanted  []JsonWeightedVariation
+		wantErr bool
+	}{
+		{
+			name:    "even split",
+			rollout: testRollout(50, 50),
+			wanted:  []JsonWeightedVariation{{Variation: 0, Weight: 50000}, {Variation: 1, Weight: 50000}},
+		},
+		{
+			name:    "thousandths of a percent",
+			rollout: testRollout(33.333, 33.333, 33.334),
+			wanted:  []JsonWeightedVariation{{Variation: 0, Weight: 33333}, {Variation: 1, Weight: 33333}, {Variation: 2, Weight: 33334}},
+		},
+		{name: "below 100", rollout: testRollout(50, 40), wantErr: true},
+		{name: "above 100", rollout: testRollout(50, 50.001), wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rollout, err := transformRolloutFromTerraformFormat(testCase.rollout, testVariations)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && !reflect.DeepEqual(rollout.Variations, testCase.wanted) {
+				t.Errorf("got weights %v but want %v", rollout.Variations, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestTransformRolloutFromLaunchDarklyFormat(t *testing.T) {
+	rollout := &JsonRollout{
+		BucketBy:   "company",
+		Variations: []JsonWeightedVariation{{Variation: 1, Weight: 12500}, {Variation: 0, Weight: 87500}},
+	}
+
+	transformed := transformRolloutFromLaunchDarklyFormat(rollout, []JsonVariations{{Value: "blue"}, {Value: "green"}})
+
+	wanted := []map[string]interface{}{{
+		"bucket_by": "company",
+		"weighted_variation": []map[string]interface{}{
+			{"variation": "green", "weight": 12.5},
+			{"variation": "blue", "weight": 87.5},
+		},
+	}}
+	if !reflect.DeepEqual(transformed, wanted) {
+		t.Errorf("got rollout %v but want %v", transformed, wanted)
+	}
+}
+
+func TestValidateVariationOrRollout(t *testing.T) {
+	testCases := []struct {
+		name              string
+		block             map[string]interface{}
+		variationRequired bool
+		wantErr           bool
+	}{
+		{name: "variation", block: map[string]interface{}{"variation": "blue"}, variationRequired: true},
+		{name: "rollout", block: map[string]interface{}{"rollout": []interface{}{testRollout(100)}}, variationRequired: true},
+		{name: "neither", block: map[string]interface{}{}, variationRequired: true, wantErr: true},
+		{name: "neither but optional", block: map[string]interface{}{}},
+		{
+			name:    "both",
+			block:   map[string]interface{}{"variation": "blue", "rollout": []interface{}{testRollout(100)}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid rollout",
+			block:   map[string]interface{}{"rollout": []interface{}{testRollout(10, 10)}},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVariationOrRollout(testCase.block, "variation", testCase.variationRequired)
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+const CLAUSE_VALUE_STRING_TYPE = "string"
+const CLAUSE_VALUE_NUMBER_TYPE = "number"
+const CLAUSE_VALUE_BOOLEAN_TYPE = "boolean"
+
+var clauseOperators = []string{
+	"in",
+	"endsWith",
+	"startsWith",
+	"matches",
+	"contains",
+	"lessThan",
+	"lessThanOrEqual",
+	"greaterThan",
+	"greaterThanOrEqual",
+	"before",
+	"after",
+	"segmentMatch",
+	"semVerEqual",
+	"semVerLessThan",
+	"semVerGreaterThan",
+}
+
+var clauseValueTypes = []string{CLAUSE_VALUE_STRING_TYPE, CLAUSE_VALUE_NUMBER_TYPE, CLAUSE_VALUE_BOOLEAN_TYPE}
+
+func clausesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"attribute": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(clauseOperators, false),
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"value_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      CLAUSE_VALUE_STRING_TYPE,
+					ValidateFunc: validation.StringInSlice(clauseValueTypes, false),
+				},
+				"negate": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func rulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"clauses": clausesSchema(),
+				"variation": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateVariationValue,
+				},
+				"rollout": rolloutSchema(),
+			},
+		},
+	}
+}
+
+func transformRulesFromTerraformFormat(rules []interface{}, variations []interface{}) ([]JsonRule, error) {
+	transformed := make([]JsonRule, len(rules))
+	for index, rawRule := range rules {
+		rule := rawRule.(map[string]interface{})
+
+		clauses, err := transformClausesFromTerraformFormat(rule["clauses"].([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		variationIndex, rollout, err := transformVariationOrRolloutFromTerraformFormat(rule, "variation", variations)
+		if err != nil {
+			return nil, err
+		}
+
+		transformed[index] = JsonRule{
+			Variation: variationIndex,
+			Rollout:   rollout,
+			Clauses:   clauses,
+		}
+	}
+	return transformed, nil
+}
+
+func transformRulesFromLaunchDarklyFormat(rules []JsonRule, variations []JsonVariations) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(rules))
+	for index, rule := range rules {
+		transformed[index] = map[string]interface{}{
+			"clauses":   transformClausesFromLaunchDarklyFormat(rule.Clauses),
+			"variation": getVariationValue(variations, rule.Variation),
+			"rollout":   transformRolloutFromLaunchDarklyFormat(rule.Rollout, variations),
+		}
+	}
+	return transformed
+}
+
+func transformClausesFromTerraformFormat(clauses []interface{}) ([]JsonClause, error) {
+	transformed := make([]JsonClause, len(clauses))
+	for index, rawClause := range clauses {
+		clause := rawClause.(map[string]interface{})
+		valueType := clause["value_type"].(string)
+
+		values := make([]interface{}, 0)
+		for _, rawValue := range clause["values"].([]interface{}) {
+			value, err := convertClauseValue(rawValue.(string), valueType)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+
+		transformed[index] = JsonClause{
+			Attribute: clause["attribute"].(string),
+			Op:        clause["operator"].(string),
+			Values:    values,
+			Negate:    clause["negate"].(bool),
+		}
+	}
+	return transformed, nil
+}
+
+func transformClausesFromLaunchDarklyFormat(clauses []JsonClause) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, len(clauses))
+	for index, clause := range clauses {
+		valueType := CLAUSE_VALUE_STRING_TYPE
+		values := make([]string, len(clause.Values))
+		for valueIndex, value := range clause.Values {
+			switch value.(type) {
+			case float64:
+				valueType = CLAUSE_VALUE_NUMBER_TYPE
+			case bool:
+				valueType = CLAUSE_VALUE_BOOLEAN_TYPE
+			}
+			values[valueIndex] = formatValue(value)
+		}
+
+		transformed[index] = map[string]interface{}{
+			"attribute":  clause.Attribute,
+			"operator":   clause.Op,
+			"values":     values,
+			"value_type": valueType,
+			"negate":     clause.Negate,
+		}
+	}
+	return transformed
+}
+
+// Clause values are always strings in the configuration, value_type tells how LaunchDarkly should
+// compare them (e.g. numbers for greaterThan, or the boolean value of a custom attribute).
+func convertClauseValue(value string, valueType string) (interface{}, error) {
+	switch valueType {
+	case CLAUSE_VALUE_NUMBER_TYPE:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid number clause value", value)
+		}
+		return number, nil
+	case CLAUSE_VALUE_BOOLEAN_TYPE:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid boolean clause value", value)
+		}
+		return boolean, nil
+	default:
+		return value, nil
+	}
+}
+
+// validateClauses checks at plan time that the values of the clauses match their value_type. Values
+// that are not known yet (interpolated from other resources) are checked when applying.
+func validateClauses(clauses []interface{}) error {
+	for _, rawClause := range clauses {
+		clause, ok := rawClause.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		valueType, _ := clause["value_type"].(string)
+		values, _ := clause["values"].([]interface{})
+		for _, rawValue := range values {
+			value, ok := rawValue.(string)
+			if !ok || value == hcl2shim.UnknownVariableValue {
+				continue
+			}
+			if _, err := convertClauseValue(value, valueType); err != nil {
+				return fmt.Errorf("clause on %v: %s", clause["attribute"], err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRules(rules []interface{}) error {
+	for _, rawRule := range rules {
+		rule, ok := rawRule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validateVariationOrRollout(rule, "variation", true); err != nil {
+			return fmt.Errorf("rule: %s", err)
+		}
+		clauses, _ := rule["clauses"].([]interface{})
+		if err := validateClauses(clauses); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+)
+
+func testClause(operator string, valueType string, values ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"attribute":  "custom",
+		"operator":   operator,
+		"values":     values,
+		"value_type": valueType,
+		"negate":     false,
+	}
+}
+
+func TestTransformClausesFromTerraformFormat(t *testing.T) {
+	testCases := []struct {
+		name    string
+		clause  map[string]interface{}
+		wanted  []interface{}
+		wantErr bool
+	}{
+		{name: "string", clause: testClause("in", "string", "a", "b"), wanted: []interface{}{"a", "b"}},
+		{name: "number", clause: testClause("greaterThan", "number", "42", "1.5"), wanted: []interface{}{float64(42), 1.5}},
+		{name: "boolean", clause: testClause("in", "boolean", "true"), wanted: []interface{}{true}},
+		{name: "invalid number", clause: testClause("lessThan", "number", "abc"), wantErr: true},
+		{name: "invalid boolean", clause: testClause("in", "boolean", "yes"), wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clauses, err := transformClausesFromTerraformFormat([]interface{}{testCase.clause})
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && !reflect.DeepEqual(clauses[0].Values, testCase.wanted) {
+				t.Errorf("got values %v but want %v", clauses[0].Values, testCase.wanted)
+			}
+		})
+	}
+}
+
+func TestTransformRulesFromLaunchDarklyFormat(t *testing.T) {
+	variation := 1
+	rules := []JsonRule{{
+		Variation: &variation,
+		Clauses: []JsonClause{
+			{Attribute: "country", Op: "in", Values: []interface{}{"ca", "us"}, Negate: true},
+			{Attribute: "age", Op: "greaterThan", Values: []interface{}{float64(18)}},
+		},
+	}}
+
+	transformed := transformRulesFromLaunchDarklyFormat(rules, []JsonVariations{{Value: "blue"}, {Value: "green"}})
+
+	wanted := []map[string]interface{}{{
+		"variation": "green",
+		"rollout":   []map[string]interface{}{},
+		"clauses": []map[string]interface{}{
+			{"attribute": "country", "operator": "in", "values": []string{"ca", "us"}, "value_type": "string", "negate": true},
+			{"attribute": "age", "operator": "greaterThan", "values": []string{"18"}, "value_type": "number", "negate": false},
+		},
+	}}
+	if !reflect.DeepEqual(transformed, wanted) {
+		t.Errorf("got rules %v but want %v", transformed, wanted)
+	}
+}
+
+// Numbers are decoded from LaunchDarkly's JSON as float64, they must be read back as they are written in
+// the configuration, e.g. epoch milliseconds for the before and after operators.
+func TestClausesNumberRoundTrip(t *testing.T) {
+	values := []interface{}{"1580000000000", "1000000", "0.25", "-3"}
+
+	clauses, err := transformClausesFromTerraformFormat([]interface{}{testClause("before", "number", values...)})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	marshaled, _ := json.Marshal(clauses)
+	var decoded []JsonClause
+	if err := json.Unmarshal(marshaled, &decoded); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	transformed := transformClausesFromLaunchDarklyFormat(decoded)
+	wanted := []string{"1580000000000", "1000000", "0.25", "-3"}
+	if !reflect.DeepEqual(transformed[0]["values"], wanted) || transformed[0]["value_type"] != "number" {
+		t.Errorf("got values %v (%v) but want %v (number)", transformed[0]["values"], transformed[0]["value_type"], wanted)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		clause  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "valid", clause: testClause("greaterThan", "number", "18")},
+		{name: "invalid value", clause: testClause("greaterThan", "number", "eighteen"), wantErr: true},
+		{name: "unknown value", clause: testClause("greaterThan", "number", hcl2shim.UnknownVariableValue)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rules := []interface{}{map[string]interface{}{
+				"variation": "blue",
+				"clauses":   []interface{}{testCase.clause},
+			}}
+			if err := validateRules(rules); (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Targets are sets so that reordering the blocks or the user keys does not show up as a change
+func targetsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"variation": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateVariationValue,
+				},
+				"values": {
+					Type:     schema.TypeSet,
+					Required: true,
+					MinItems: 1,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func transformTargetsFromTerraformFormat(targets interface{}, variations []interface{}) ([]JsonTarget, error) {
+	transformed := make([]JsonTarget, 0)
+	targetedVariations := make(map[int]bool)
+	for _, rawTarget := range getListOrSet(targets) {
+		target := rawTarget.(map[string]interface{})
+
+		variationIndex, err := getVariationIndex(variations, target["variation"].(string))
+		if err != nil {
+			return nil, err
+		}
+		if targetedVariations[variationIndex] {
+			return nil, fmt.Errorf("variation %s is targeted by more than one targets block", target["variation"])
+		}
+		targetedVariations[variationIndex] = true
+
+		values := make([]string, 0)
+		for _, value := range getListOrSet(target["values"]) {
+			values = append(values, value.(string))
+		}
+		sort.Strings(values)
+
+		transformed = append(transformed, JsonTarget{
+			Values:    values,
+			Variation: variationIndex,
+		})
+	}
+
+	sort.Slice(transformed, func(i, j int) bool {
+		return transformed[i].Variation < transformed[j].Variation
+	})
+	return transformed, nil
+}
+
+func transformTargetsFromLaunchDarklyFormat(targets []JsonTarget, variations []JsonVariations) []map[string]interface{} {
+	transformed := make([]map[string]interface{}, 0, len(targets))
+	for _, target := range targets {
+		// LaunchDarkly keeps the targets of a variation once all its users are removed
+		if len(target.Values) == 0 {
+			continue
+		}
+		variationIndex := target.Variation
+		transformed = append(transformed, map[string]interface{}{
+			"variation": getVariationValue(variations, &variationIndex),
+			"values":    target.Values,
+		})
+	}
+	return transformed
+}
+
+// getListOrSet returns the items of an attribute that is either a list or a set, since nested sets
+// are read as *schema.Set while sets built by hand are plain lists.
+func getListOrSet(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case *schema.Set:
+		return typed.List()
+	case []interface{}:
+		return typed
+	default:
+		return nil
+	}
+}
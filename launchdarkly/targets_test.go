@@ -0,0 +1,61 @@
+package launchdarkly
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestTransformTargetsFromTerraformFormat(t *testing.T) {
+	testCases := []struct {
+		name    string
+		targets []interface{}
+		wanted  []JsonTarget
+		wantErr bool
+	}{
+		{
+			name: "sorted by variation and user key",
+			targets: []interface{}{
+				map[string]interface{}{"variation": "red", "values": schema.NewSet(schema.HashString, []interface{}{"qa-2", "qa-1"})},
+				map[string]interface{}{"variation": "blue", "values": []interface{}{"internal"}},
+			},
+			wanted: []JsonTarget{
+				{Values: []string{"internal"}, Variation: 0},
+				{Values: []string{"qa-1", "qa-2"}, Variation: 2},
+			},
+		},
+		{
+			name:    "no targets",
+			targets: []interface{}{},
+			wanted:  []JsonTarget{},
+		},
+		{
+			name: "unknown variation",
+			targets: []interface{}{
+				map[string]interface{}{"variation": "yellow", "values": []interface{}{"qa-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "variation targeted twice",
+			targets: []interface{}{
+				map[string]interface{}{"variation": "blue", "values": []interface{}{"qa-1"}},
+				map[string]interface{}{"variation": "blue", "values": []interface{}{"qa-2"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			targets, err := transformTargetsFromTerraformFormat(testCase.targets, testVariations)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && !reflect.DeepEqual(targets, testCase.wanted) {
+				t.Errorf("got targets %v but want %v", targets, testCase.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testResourceServer describes an in-memory LaunchDarkly resource served by newTestResourceServer.
+type testResourceServer struct {
+	// Path of the resource, e.g. /api/v2/webhooks/my-id
+	path string
+	// Path creating the resource with a POST, if any
+	createPath string
+	// ID that LaunchDarkly assigns to the resource when it is created, if any
+	id string
+	// Resource that already exists, if any
+	document map[string]interface{}
+	// Transforms the responses, e.g. to hide the secrets that LaunchDarkly does not return
+	respond func(r *http.Request, response map[string]interface{}) map[string]interface{}
+	// Serves the requests to other paths, e.g. actions on the resource
+	handle func(w http.ResponseWriter, r *http.Request)
+}
+
+// newTestResourceServer keeps the resource created through it, applying the JSON patches it receives so
+// that resources can be read back after being written. Requests to other paths are not found.
+func newTestResourceServer(t *testing.T, config testResourceServer) (*httptest.Server, *map[string]interface{}) {
+	document := config.document
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == "POST" && len(config.createPath) > 0 && r.URL.Path == config.createPath:
+			json.Unmarshal(body, &document)
+			if len(config.id) > 0 {
+				document["_id"] = config.id
+			}
+			w.WriteHeader(201)
+		case r.URL.Path != config.path && config.handle != nil:
+			config.handle(w, r)
+			return
+		case document == nil || r.URL.Path != config.path:
+			w.WriteHeader(404)
+			return
+		case r.Method == "PATCH":
+			var operations []map[string]interface{}
+			if err := json.Unmarshal(body, &operations); err != nil {
+				t.Errorf("invalid patch: %s", err)
+			}
+			for _, operation := range operations {
+				applyTestPatchOperation(t, document, operation)
+			}
+			w.WriteHeader(200)
+		default:
+			w.WriteHeader(200)
+		}
+
+		response := make(map[string]interface{})
+		for key, value := range document {
+			response[key] = value
+		}
+		if config.respond != nil {
+			response = config.respond(r, response)
+		}
+		marshaled, _ := json.Marshal(response)
+		w.Write(marshaled)
+	}))
+
+	return server, &document
+}
+
+// applyTestPatchOperation applies a "replace" or a "remove" operation of a JSON patch to a document.
+func applyTestPatchOperation(t *testing.T, document map[string]interface{}, operation map[string]interface{}) {
+	segments := strings.Split(strings.TrimPrefix(operation["path"].(string), "/"), "/")
+	current := document
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[segment] = next
+		}
+		current = next
+	}
+	field := segments[len(segments)-1]
+
+	switch operation["op"] {
+	case "replace":
+		// Round trip the value so that it has the same types as a decoded response
+		marshaled, _ := json.Marshal(operation["value"])
+		var value interface{}
+		json.Unmarshal(marshaled, &value)
+		current[field] = value
+	case "remove":
+		if _, found := current[field]; !found {
+			t.Errorf("cannot remove the missing field: %v", operation)
+		}
+		delete(current, field)
+	default:
+		t.Errorf("unexpected patch operation: %v", operation)
+	}
+}
@@ -0,0 +1,107 @@
+package launchdarkly
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type transportConfig struct {
+	Timeout            time.Duration
+	ProxyUrl           string
+	CaCertFile         string
+	CaCertPem          string
+	InsecureSkipVerify bool
+	ClientCertFile     string
+	ClientKeyFile      string
+	ClientCertPem      string
+	ClientKeyPem       string
+}
+
+// newHttpClient builds the HTTP client dedicated to the provider. Without a proxy_url, the standard
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables are honored.
+func newHttpClient(config transportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if len(config.ProxyUrl) > 0 {
+		proxyUrl, err := url.Parse(config.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("proxy_url is not a valid URL: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	tlsConfig, err := newTlsConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
+	}, nil
+}
+
+func newTlsConfig(config transportConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	caCertPem, err := readPemOrFile("ca_cert", config.CaCertPem, config.CaCertFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(caCertPem) > 0 {
+		// The private CA is added to the system ones so that LaunchDarkly can still be reached directly
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCertPem) {
+			return nil, errors.New("no valid PEM certificate was found in ca_cert_pem or ca_cert_file")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCertPem, err := readPemOrFile("client_cert", config.ClientCertPem, config.ClientCertFile)
+	if err != nil {
+		return nil, err
+	}
+	clientKeyPem, err := readPemOrFile("client_key", config.ClientKeyPem, config.ClientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(clientCertPem) > 0 || len(clientKeyPem) > 0 {
+		if len(clientCertPem) == 0 || len(clientKeyPem) == 0 {
+			return nil, errors.New("a client certificate and its private key must be provided together")
+		}
+		certificate, err := tls.X509KeyPair(clientCertPem, clientKeyPem)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+func readPemOrFile(name string, pem string, file string) ([]byte, error) {
+	if len(pem) > 0 {
+		return []byte(pem), nil
+	}
+	if len(file) == 0 {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s_file: %s", name, err)
+	}
+	return content, nil
+}
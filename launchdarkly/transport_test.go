@@ -0,0 +1,185 @@
+package launchdarkly
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-launchdarkly"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	return string(certPem), string(keyPem)
+}
+
+func serverCaPem(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestNewHttpClientTrust(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name    string
+		config  transportConfig
+		wantErr bool
+	}{
+		{
+			name:    "unknown certificate authority",
+			config:  transportConfig{},
+			wantErr: true,
+		},
+		{
+			name:   "with the server certificate authority",
+			config: transportConfig{CaCertPem: serverCaPem(server)},
+		},
+		{
+			name:   "skipping verification",
+			config: transportConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, err := newHttpClient(testCase.config)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("got error (%v) but wanted error: %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHttpClientClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(401)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPem, keyPem := generateTestCertificate(t)
+	client, err := newHttpClient(transportConfig{
+		CaCertPem:     serverCaPem(server),
+		ClientCertPem: certPem,
+		ClientKeyPem:  keyPem,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("got HTTP %d but want 200", resp.StatusCode)
+	}
+}
+
+func TestNewHttpClientProxy(t *testing.T) {
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(200)
+	}))
+	defer proxy.Close()
+
+	client, err := newHttpClient(transportConfig{ProxyUrl: proxy.URL})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := client.Get("http://app.launchdarkly.invalid/api/v2/projects")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://app.launchdarkly.invalid/api/v2/projects" {
+		t.Errorf("the request was not sent through the proxy, got (%s)", proxied)
+	}
+}
+
+func TestNewHttpClientErrors(t *testing.T) {
+	certPem, keyPem := generateTestCertificate(t)
+
+	testCases := []struct {
+		name   string
+		config transportConfig
+	}{
+		{
+			name:   "invalid certificate authority",
+			config: transportConfig{CaCertPem: "not a certificate"},
+		},
+		{
+			name:   "missing certificate authority file",
+			config: transportConfig{CaCertFile: "/does/not/exist.pem"},
+		},
+		{
+			name:   "client certificate without key",
+			config: transportConfig{ClientCertPem: certPem},
+		},
+		{
+			name:   "client key without certificate",
+			config: transportConfig{ClientKeyPem: keyPem},
+		},
+		{
+			name:   "mismatched client certificate",
+			config: transportConfig{ClientCertPem: certPem, ClientKeyPem: "not a key"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := newHttpClient(testCase.config); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -1,29 +1,110 @@
 package launchdarkly
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-const rootUrl = "https://app.launchdarkly.com/api/v2"
+const defaultApiHost = "https://app.launchdarkly.com"
+const apiPath = "/api/v2"
 
-func getProjectCreateUrl() string {
-	return fmt.Sprintf("%s/projects", rootUrl)
+func (c *Client) getRootUrl() string {
+	host := c.ApiHost
+	if len(host) == 0 {
+		host = defaultApiHost
+	}
+	return strings.TrimSuffix(host, "/") + apiPath
 }
 
-func getProjectUrl(project string) string {
-	return fmt.Sprintf("%s/projects/%s", rootUrl, project)
+func (c *Client) getProjectCreateUrl() string {
+	return fmt.Sprintf("%s/projects", c.getRootUrl())
 }
 
-func getFlagCreateUrl(project string) string {
-	return fmt.Sprintf("%s/flags/%s", rootUrl, project)
+func (c *Client) getProjectUrl(project string) string {
+	return fmt.Sprintf("%s/projects/%s", c.getRootUrl(), project)
 }
 
-func getFlagUrl(project string, flag string) string {
-	return fmt.Sprintf("%s/flags/%s/%s", rootUrl, project, flag)
+func (c *Client) getFlagCreateUrl(project string) string {
+	return fmt.Sprintf("%s/flags/%s", c.getRootUrl(), project)
 }
 
-func getEnvironmentCreateUrl(project string) string {
-	return fmt.Sprintf("%s/projects/%s/environments", rootUrl, project)
+func (c *Client) getFlagUrl(project string, flag string) string {
+	return fmt.Sprintf("%s/flags/%s/%s", c.getRootUrl(), project, flag)
 }
 
-func getEnvironmentUrl(project string, environment string) string {
-	return fmt.Sprintf("%s/projects/%s/environments/%s", rootUrl, project, environment)
+func (c *Client) getEnvironmentCreateUrl(project string) string {
+	return fmt.Sprintf("%s/projects/%s/environments", c.getRootUrl(), project)
+}
+
+func (c *Client) getEnvironmentUrl(project string, environment string) string {
+	return fmt.Sprintf("%s/projects/%s/environments/%s", c.getRootUrl(), project, environment)
+}
+
+func (c *Client) getSegmentCreateUrl(project string, environment string) string {
+	return fmt.Sprintf("%s/segments/%s/%s", c.getRootUrl(), project, environment)
+}
+
+func (c *Client) getSegmentUrl(project string, environment string, segment string) string {
+	return fmt.Sprintf("%s/segments/%s/%s/%s", c.getRootUrl(), project, environment, segment)
+}
+
+func (c *Client) getWebhookCreateUrl() string {
+	return fmt.Sprintf("%s/webhooks", c.getRootUrl())
+}
+
+func (c *Client) getWebhookUrl(webhook string) string {
+	return fmt.Sprintf("%s/webhooks/%s", c.getRootUrl(), webhook)
+}
+
+func (c *Client) getCustomRoleCreateUrl() string {
+	return fmt.Sprintf("%s/roles", c.getRootUrl())
+}
+
+func (c *Client) getCustomRoleUrl(role string) string {
+	return fmt.Sprintf("%s/roles/%s", c.getRootUrl(), role)
+}
+
+func (c *Client) getMemberCreateUrl() string {
+	return fmt.Sprintf("%s/members", c.getRootUrl())
+}
+
+func (c *Client) getMembersPageUrl(limit int, offset int) string {
+	return fmt.Sprintf("%s/members?limit=%d&offset=%d", c.getRootUrl(), limit, offset)
+}
+
+func (c *Client) getMemberUrl(member string) string {
+	return fmt.Sprintf("%s/members/%s", c.getRootUrl(), member)
+}
+
+func (c *Client) getAccessTokenCreateUrl() string {
+	return fmt.Sprintf("%s/tokens", c.getRootUrl())
+}
+
+func (c *Client) getAccessTokenUrl(token string) string {
+	return fmt.Sprintf("%s/tokens/%s", c.getRootUrl(), token)
+}
+
+// getAccessTokenResetUrl returns the URL resetting a token, the current secret expiring at the given
+// Unix time in milliseconds or immediately when it is not positive.
+func (c *Client) getAccessTokenResetUrl(token string, expiry int) string {
+	if expiry > 0 {
+		return fmt.Sprintf("%s/tokens/%s/reset?expiry=%d", c.getRootUrl(), token, expiry)
+	}
+	return fmt.Sprintf("%s/tokens/%s/reset", c.getRootUrl(), token)
+}
+
+func (c *Client) getDestinationCreateUrl(project string, environment string) string {
+	return fmt.Sprintf("%s/destinations/%s/%s", c.getRootUrl(), project, environment)
+}
+
+func (c *Client) getDestinationUrl(project string, environment string, destination string) string {
+	return fmt.Sprintf("%s/destinations/%s/%s/%s", c.getRootUrl(), project, environment, destination)
+}
+
+func (c *Client) getMetricCreateUrl(project string) string {
+	return fmt.Sprintf("%s/metrics/%s", c.getRootUrl(), project)
+}
+
+func (c *Client) getMetricUrl(project string, metric string) string {
+	return fmt.Sprintf("%s/metrics/%s/%s", c.getRootUrl(), project, metric)
 }
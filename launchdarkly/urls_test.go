@@ -7,9 +7,45 @@ import (
 const launchDarklyApiUrl = "https://app.launchdarkly.com/api/v2/"
 const aProjectName = "my-project"
 
+var aClient = &Client{ApiHost: defaultApiHost}
+
+func TestGetRootUrl(t *testing.T) {
+	testCases := []struct {
+		name      string
+		apiHost   string
+		wantedUrl string
+	}{
+		{
+			name:      "default host",
+			apiHost:   "",
+			wantedUrl: "https://app.launchdarkly.com/api/v2",
+		},
+		{
+			name:      "custom host",
+			apiHost:   "https://app.launchdarkly.us",
+			wantedUrl: "https://app.launchdarkly.us/api/v2",
+		},
+		{
+			name:      "custom host with trailing slash",
+			apiHost:   "http://localhost:8080/",
+			wantedUrl: "http://localhost:8080/api/v2",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &Client{ApiHost: testCase.apiHost}
+			returnedUrl := client.getRootUrl()
+			if returnedUrl != testCase.wantedUrl {
+				t.Errorf("getRootUrl expected return value was '%s' but got '%s'", testCase.wantedUrl, returnedUrl)
+			}
+		})
+	}
+}
+
 func TestGetProjectCreateUrl(t *testing.T) {
 	expectedUrl := launchDarklyApiUrl + "projects"
-	returnedUrl := getProjectCreateUrl()
+	returnedUrl := aClient.getProjectCreateUrl()
 	if returnedUrl != expectedUrl {
 		t.Errorf("getProjectCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
@@ -17,7 +53,7 @@ func TestGetProjectCreateUrl(t *testing.T) {
 
 func TestGetProjectUrl(t *testing.T) {
 	expectedUrl := launchDarklyApiUrl + "projects/" + aProjectName
-	returnedUrl := getProjectUrl(aProjectName)
+	returnedUrl := aClient.getProjectUrl(aProjectName)
 	if returnedUrl != expectedUrl {
 		t.Errorf("getProjectUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
@@ -25,7 +61,7 @@ func TestGetProjectUrl(t *testing.T) {
 
 func TestGetFlagCreateUrl(t *testing.T) {
 	expectedUrl := launchDarklyApiUrl + "flags/" + aProjectName
-	returnedUrl := getFlagCreateUrl(aProjectName)
+	returnedUrl := aClient.getFlagCreateUrl(aProjectName)
 	if returnedUrl != expectedUrl {
 		t.Errorf("getFlagCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
@@ -34,7 +70,7 @@ func TestGetFlagCreateUrl(t *testing.T) {
 func TestGetFlagUrl(t *testing.T) {
 	aFlagName := "my-super-flag"
 	expectedUrl := launchDarklyApiUrl + "flags/" + aProjectName + "/" + aFlagName
-	returnedUrl := getFlagUrl(aProjectName, aFlagName)
+	returnedUrl := aClient.getFlagUrl(aProjectName, aFlagName)
 	if returnedUrl != expectedUrl {
 		t.Errorf("getFlagUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
@@ -42,7 +78,7 @@ func TestGetFlagUrl(t *testing.T) {
 
 func TestGetEnvironmentCreateUrl(t *testing.T) {
 	expectedUrl := launchDarklyApiUrl + "projects/" + aProjectName + "/environments"
-	returnedUrl := getEnvironmentCreateUrl(aProjectName)
+	returnedUrl := aClient.getEnvironmentCreateUrl(aProjectName)
 	if returnedUrl != expectedUrl {
 		t.Errorf("getEnvironmentCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
@@ -51,8 +87,154 @@ func TestGetEnvironmentCreateUrl(t *testing.T) {
 func TestGetEnvironmentUrl(t *testing.T) {
 	anEnvironmentName := "my-marvelous-environment"
 	expectedUrl := launchDarklyApiUrl + "projects/" + aProjectName + "/environments/" + anEnvironmentName
-	returnedUrl := getEnvironmentUrl(aProjectName, anEnvironmentName)
+	returnedUrl := aClient.getEnvironmentUrl(aProjectName, anEnvironmentName)
 	if returnedUrl != expectedUrl {
 		t.Errorf("getEnvironmentUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
 	}
 }
+
+func TestGetSegmentCreateUrl(t *testing.T) {
+	anEnvironmentName := "my-marvelous-environment"
+	expectedUrl := launchDarklyApiUrl + "segments/" + aProjectName + "/" + anEnvironmentName
+	returnedUrl := aClient.getSegmentCreateUrl(aProjectName, anEnvironmentName)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getSegmentCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetSegmentUrl(t *testing.T) {
+	anEnvironmentName := "my-marvelous-environment"
+	aSegmentName := "my-beta-testers"
+	expectedUrl := launchDarklyApiUrl + "segments/" + aProjectName + "/" + anEnvironmentName + "/" + aSegmentName
+	returnedUrl := aClient.getSegmentUrl(aProjectName, anEnvironmentName, aSegmentName)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getSegmentUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetWebhookCreateUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "webhooks"
+	returnedUrl := aClient.getWebhookCreateUrl()
+	if returnedUrl != expectedUrl {
+		t.Errorf("getWebhookCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetWebhookUrl(t *testing.T) {
+	aWebhookId := "57be1db38b75bf0772d11384"
+	expectedUrl := launchDarklyApiUrl + "webhooks/" + aWebhookId
+	returnedUrl := aClient.getWebhookUrl(aWebhookId)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getWebhookUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetCustomRoleCreateUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "roles"
+	returnedUrl := aClient.getCustomRoleCreateUrl()
+	if returnedUrl != expectedUrl {
+		t.Errorf("getCustomRoleCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetCustomRoleUrl(t *testing.T) {
+	aRoleKey := "flag-maintainers"
+	expectedUrl := launchDarklyApiUrl + "roles/" + aRoleKey
+	returnedUrl := aClient.getCustomRoleUrl(aRoleKey)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getCustomRoleUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetMemberCreateUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "members"
+	returnedUrl := aClient.getMemberCreateUrl()
+	if returnedUrl != expectedUrl {
+		t.Errorf("getMemberCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetMembersPageUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "members?limit=20&offset=40"
+	returnedUrl := aClient.getMembersPageUrl(20, 40)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getMembersPageUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetMemberUrl(t *testing.T) {
+	aMemberId := "569f183514f4432160000007"
+	expectedUrl := launchDarklyApiUrl + "members/" + aMemberId
+	returnedUrl := aClient.getMemberUrl(aMemberId)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getMemberUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetAccessTokenCreateUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "tokens"
+	returnedUrl := aClient.getAccessTokenCreateUrl()
+	if returnedUrl != expectedUrl {
+		t.Errorf("getAccessTokenCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetAccessTokenUrl(t *testing.T) {
+	aTokenId := "5e1e8a5c4f0bd20831d8a5f7"
+	expectedUrl := launchDarklyApiUrl + "tokens/" + aTokenId
+	returnedUrl := aClient.getAccessTokenUrl(aTokenId)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getAccessTokenUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetAccessTokenResetUrl(t *testing.T) {
+	aTokenId := "5e1e8a5c4f0bd20831d8a5f7"
+	expectedUrl := launchDarklyApiUrl + "tokens/" + aTokenId + "/reset"
+	returnedUrl := aClient.getAccessTokenResetUrl(aTokenId, -1)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getAccessTokenResetUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+
+	expectedUrl = launchDarklyApiUrl + "tokens/" + aTokenId + "/reset?expiry=1580000000000"
+	returnedUrl = aClient.getAccessTokenResetUrl(aTokenId, 1580000000000)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getAccessTokenResetUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetDestinationCreateUrl(t *testing.T) {
+	anEnvironmentName := "my-marvelous-environment"
+	expectedUrl := launchDarklyApiUrl + "destinations/" + aProjectName + "/" + anEnvironmentName
+	returnedUrl := aClient.getDestinationCreateUrl(aProjectName, anEnvironmentName)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getDestinationCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetDestinationUrl(t *testing.T) {
+	anEnvironmentName := "my-marvelous-environment"
+	aDestinationId := "5e1e8a5c4f0bd20831d8a5f8"
+	expectedUrl := launchDarklyApiUrl + "destinations/" + aProjectName + "/" + anEnvironmentName + "/" + aDestinationId
+	returnedUrl := aClient.getDestinationUrl(aProjectName, anEnvironmentName, aDestinationId)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getDestinationUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetMetricCreateUrl(t *testing.T) {
+	expectedUrl := launchDarklyApiUrl + "metrics/" + aProjectName
+	returnedUrl := aClient.getMetricCreateUrl(aProjectName)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getMetricCreateUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
+
+func TestGetMetricUrl(t *testing.T) {
+	aMetricKey := "my-marvelous-metric"
+	expectedUrl := launchDarklyApiUrl + "metrics/" + aProjectName + "/" + aMetricKey
+	returnedUrl := aClient.getMetricUrl(aProjectName, aMetricKey)
+	if returnedUrl != expectedUrl {
+		t.Errorf("getMetricUrl expected return value was '%s' but got '%s'", expectedUrl, returnedUrl)
+	}
+}
@@ -3,7 +3,10 @@ package launchdarkly
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
 )
 
 var supportedVariationsType = [3]string{VARIATIONS_NUMBER_KIND, VARIATIONS_STRING_KIND, VARIATIONS_BOOLEAN_KIND}
@@ -88,3 +91,115 @@ func validateColor(v interface{}, k string) ([]string, []error) {
 
 	return nil, nil
 }
+
+func validateApiHost(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid URL: %s", k, value)}
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return nil, []error{fmt.Errorf("%s must be an absolute http(s) URL: %s", k, value)}
+	}
+
+	return nil, nil
+}
+
+func validateProxyUrl(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid URL: %s", k, value)}
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "socks5") || len(parsed.Host) == 0 {
+		return nil, []error{fmt.Errorf("%s must be an absolute http(s) or socks5 URL: %s", k, value)}
+	}
+
+	return nil, nil
+}
+
+func validateApiVersion(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	if _, err := time.Parse("20060102", value); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a LaunchDarkly API version such as %s: %s", k, defaultApiVersion, value)}
+	}
+
+	return nil, nil
+}
+
+func validateWebhookUrl(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return nil, []error{fmt.Errorf("%s must be an absolute http(s) URL: %s", k, value)}
+	}
+
+	return nil, nil
+}
+
+// The parent of each type of resource in a resource specifier, e.g. proj/*:env/production:flag/*.
+// Top level resources have no parent.
+var policyResourceParents = map[string]string{
+	"acct":                      "",
+	"code-reference-repository": "",
+	"integration":               "",
+	"member":                    "",
+	"proj":                      "",
+	"relay-proxy-config":        "",
+	"role":                      "",
+	"service-token":             "",
+	"webhook":                   "",
+	"env":                       "proj",
+	"metric":                    "proj",
+	"destination":               "env",
+	"flag":                      "env",
+	"segment":                   "env",
+}
+
+var policyResourcePattern = regexp.MustCompile(`^([a-z-]+)(?:/([a-zA-Z0-9._*-]+))?(?:;([a-zA-Z0-9._*-]+(?:,[a-zA-Z0-9._*-]+)*))?$`)
+var policyActionPattern = regexp.MustCompile(`^[a-zA-Z*]+$`)
+
+// validatePolicyResource checks the syntax of a resource specifier: resources separated by colons, each
+// made of a type, an optional name and optional tags, e.g. proj/*:env/production;critical:flag/*.
+func validatePolicyResource(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	parent := ""
+	for _, resource := range strings.Split(value, ":") {
+		matches := policyResourcePattern.FindStringSubmatch(resource)
+		if matches == nil {
+			return nil, []error{fmt.Errorf("%s is not a valid resource specifier, %s should look like type/name;tags: %s", k, resource, value)}
+		}
+
+		resourceType := matches[1]
+		expectedParent, known := policyResourceParents[resourceType]
+		if !known {
+			return nil, []error{fmt.Errorf("%s is not a valid resource specifier, unknown resource type %s: %s", k, resourceType, value)}
+		}
+		if expectedParent != parent {
+			if len(expectedParent) == 0 {
+				return nil, []error{fmt.Errorf("%s is not a valid resource specifier, %s must be the first resource: %s", k, resourceType, value)}
+			}
+			return nil, []error{fmt.Errorf("%s is not a valid resource specifier, %s must follow %s: %s", k, resourceType, expectedParent, value)}
+		}
+		parent = resourceType
+	}
+
+	return nil, nil
+}
+
+func validatePolicyAction(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	if !policyActionPattern.MatchString(value) {
+		return nil, []error{fmt.Errorf("%s is not a valid action, actions are names such as updateOn or *: %s", k, value)}
+	}
+
+	return nil, nil
+}
@@ -132,8 +132,7 @@ func TestValidateVariationValue(t *testing.T) {
 			wantedErr: []error{errors.New(fmt.Sprintf("%s cannot be an empty string", "a-key"))},
 		},
 		{
-			name:
-			"with invalid type as value",
+			name:      "with invalid type as value",
 			v:         1,
 			k:         "a-key",
 			wantedErr: []error{errors.New(fmt.Sprintf("expected %s to be a string", "a-key"))},
@@ -177,6 +176,202 @@ func TestValidateColor(t *testing.T) {
 	}
 }
 
+func TestValidateApiHost(t *testing.T) {
+	testCases := []struct {
+		name      string
+		v         interface{}
+		k         string
+		wantedErr []error
+	}{
+		{
+			name:      "expected",
+			v:         "https://app.launchdarkly.com",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "with a local http server",
+			v:         "http://localhost:8080/",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "without scheme",
+			v:         "app.launchdarkly.com",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be an absolute http(s) URL: %s", "a-key", "app.launchdarkly.com")},
+		},
+		{
+			name:      "with unparsable URL",
+			v:         "https://%zz",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s is not a valid URL: %s", "a-key", "https://%zz")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validateApiHost(testCase.v, testCase.k)
+			testValidateVerifyGeneric(t, errs, testCase.wantedErr)
+		})
+	}
+}
+
+func TestValidateProxyUrl(t *testing.T) {
+	testCases := []struct {
+		name      string
+		v         interface{}
+		k         string
+		wantedErr []error
+	}{
+		{
+			name:      "expected",
+			v:         "http://proxy.corp:3128",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "socks proxy",
+			v:         "socks5://127.0.0.1:1080",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "without scheme",
+			v:         "proxy.corp:3128",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be an absolute http(s) or socks5 URL: %s", "a-key", "proxy.corp:3128")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validateProxyUrl(testCase.v, testCase.k)
+			testValidateVerifyGeneric(t, errs, testCase.wantedErr)
+		})
+	}
+}
+
+func TestValidateApiVersion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		v         interface{}
+		k         string
+		wantedErr []error
+	}{
+		{
+			name:      "expected",
+			v:         "20191212",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "not a date",
+			v:         "beta",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be a LaunchDarkly API version such as %s: %s", "a-key", defaultApiVersion, "beta")},
+		},
+		{
+			name:      "formatted date",
+			v:         "2019-12-12",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be a LaunchDarkly API version such as %s: %s", "a-key", defaultApiVersion, "2019-12-12")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validateApiVersion(testCase.v, testCase.k)
+			testValidateVerifyGeneric(t, errs, testCase.wantedErr)
+		})
+	}
+}
+
+func TestValidateWebhookUrl(t *testing.T) {
+	testCases := []struct {
+		name      string
+		v         interface{}
+		k         string
+		wantedErr []error
+	}{
+		{
+			name:      "expected",
+			v:         "https://audit.example.com/launchdarkly",
+			k:         "a-key",
+			wantedErr: nil,
+		},
+		{
+			name:      "relative",
+			v:         "/launchdarkly",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be an absolute http(s) URL: %s", "a-key", "/launchdarkly")},
+		},
+		{
+			name:      "other scheme",
+			v:         "ftp://audit.example.com",
+			k:         "a-key",
+			wantedErr: []error{fmt.Errorf("%s must be an absolute http(s) URL: %s", "a-key", "ftp://audit.example.com")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validateWebhookUrl(testCase.v, testCase.k)
+			testValidateVerifyGeneric(t, errs, testCase.wantedErr)
+		})
+	}
+}
+
+func TestValidatePolicyResource(t *testing.T) {
+	testCases := []struct {
+		name    string
+		v       string
+		wantErr bool
+	}{
+		{name: "all flags of production", v: "proj/*:env/production:flag/*"},
+		{name: "tagged environments", v: "proj/my-project:env/*;critical,hipaa:flag/*"},
+		{name: "top level resource", v: "member/*"},
+		{name: "account", v: "acct"},
+		{name: "unknown type", v: "proj/*:environment/production", wantErr: true},
+		{name: "missing parent", v: "flag/*", wantErr: true},
+		{name: "wrong parent", v: "proj/*:flag/*", wantErr: true},
+		{name: "empty name", v: "proj/", wantErr: true},
+		{name: "empty resource", v: "proj/*::env/*", wantErr: true},
+		{name: "invalid characters", v: "proj/my project", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validatePolicyResource(testCase.v, "a-key")
+			if (len(errs) > 0) != testCase.wantErr {
+				t.Errorf("got errors (%v) but wanted error: %v", errs, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyAction(t *testing.T) {
+	testCases := []struct {
+		name    string
+		v       string
+		wantErr bool
+	}{
+		{name: "all actions", v: "*"},
+		{name: "action", v: "updateOn"},
+		{name: "invalid characters", v: "update-on", wantErr: true},
+		{name: "empty", v: "", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, errs := validatePolicyAction(testCase.v, "a-key")
+			if (len(errs) > 0) != testCase.wantErr {
+				t.Errorf("got errors (%v) but wanted error: %v", errs, testCase.wantErr)
+			}
+		})
+	}
+}
+
 func testValidateVerifyGeneric(t *testing.T, errs []error, wantedErr []error) {
 	if !reflect.DeepEqual(errs, wantedErr) {
 		t.Errorf("got error (%s) but want (%s)", errs, wantedErr)
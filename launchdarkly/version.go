@@ -0,0 +1,18 @@
+package launchdarkly
+
+import "fmt"
+
+// ProviderVersion is set at build time through -ldflags, see the Makefile.
+var ProviderVersion = "dev"
+
+// The LaunchDarkly API version the provider was written against. Pinning it keeps the behavior of
+// the API stable when LaunchDarkly changes the default version of access tokens.
+const defaultApiVersion = "20191212"
+
+func getUserAgent(terraformVersion string) string {
+	if len(terraformVersion) == 0 {
+		// Terraform 0.11 and earlier do not send their version to providers
+		terraformVersion = "0.11+compatible"
+	}
+	return fmt.Sprintf("terraform-provider-launchdarkly/%s terraform/%s", ProviderVersion, terraformVersion)
+}
@@ -1,3 +1,5 @@
+# The access token can also be provided through the LAUNCHDARKLY_ACCESS_TOKEN environment variable
+# or read from a file with access_token_file
 variable "ld_access_token" {}
 
 provider "launchdarkly" {
@@ -21,6 +23,10 @@ resource "launchdarkly_environment" "hipaa" {
   name = "HIPAA"
   key = "hipaa"
   color = "FF00FF"
+  secure_mode = true
+  require_comments = true
+  confirm_changes = true
+  tags = ["compliance"]
 }
 
 resource "launchdarkly_feature_flag" "my-flag" {
@@ -34,6 +40,38 @@ resource "launchdarkly_feature_flag" "my-flag" {
     name = "Some Property"
     value = ["value1", "value2", "value3"]
   }]
+  environment {
+    key = "${launchdarkly_environment.dev.key}"
+    on = true
+  }
+}
+
+resource "launchdarkly_feature_flag_environment" "my-flag-hipaa" {
+  project_key = "${launchdarkly_project.my-project.key}"
+  flag_key = "${launchdarkly_feature_flag.my-flag.key}"
+  env_key = "${launchdarkly_environment.hipaa.key}"
+  on = true
+  track_events = false
+  fallthrough {
+    variation = "true"
+  }
+  off_variation = "false"
+}
+
+resource "launchdarkly_segment" "beta-testers" {
+  project_key = "${launchdarkly_project.my-project.key}"
+  env_key = "${launchdarkly_environment.dev.key}"
+  key = "beta-testers"
+  name = "Beta testers"
+  tags = ["beta"]
+  included = ["internal-user"]
+  rules {
+    clauses {
+      attribute = "email"
+      operator = "endsWith"
+      values = ["@example.com"]
+    }
+  }
 }
 
 data "launchdarkly_project" "data_project" {
@@ -49,3 +87,9 @@ data "launchdarkly_feature_flag" "data_feature_flag" {
   project_key = "${launchdarkly_environment.dev.project_key}"
   key = "${launchdarkly_feature_flag.my-flag.key}"
 }
+
+data "launchdarkly_segment" "data_segment" {
+  project_key = "${launchdarkly_segment.beta-testers.project_key}"
+  env_key = "${launchdarkly_segment.beta-testers.env_key}"
+  key = "${launchdarkly_segment.beta-testers.key}"
+}